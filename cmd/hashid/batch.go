@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+)
+
+type batchConfig struct {
+	config
+	column    string
+	header    bool
+	delimiter string
+	format    string
+	workers   int
+}
+
+type batchRecord struct {
+	line  int
+	input string
+	err   error
+}
+
+type batchResult struct {
+	batchRecord
+	id string
+}
+
+// runBatch generates IDs for each line (or CSV column value)
+// of the input, preserving the input order
+func runBatch(args []string) int {
+	conf := batchConfig{}
+
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	conf.registerFlags(fs)
+	fs.StringVar(&conf.column, "column", "", "CSV column to hash, by header name or 1-based index")
+	fs.BoolVar(&conf.header, "header", false, "Skip the CSV header row when -column is an index")
+	fs.StringVar(&conf.delimiter, "delimiter", ",", "CSV field delimiter")
+	fs.StringVar(&conf.format, "format", "tsv", "Output format (tsv, csv, jsonl)")
+	fs.IntVar(&conf.workers, "workers", runtime.NumCPU(), "Number of parallel workers")
+	fs.Usage = batchUsage
+	fs.Parse(args)
+
+	if len(conf.parts) > 0 {
+		fmt.Fprintln(os.Stderr, "Error: -part is not supported in batch mode")
+		return exitUsage
+	}
+
+	if fs.NArg() > 1 {
+		fmt.Fprint(os.Stderr, "Error: at most one input file is supported\n\n")
+		batchUsage()
+		return exitUsage
+	}
+
+	if conf.workers < 1 {
+		conf.workers = 1
+	}
+
+	write, flush, err := newBatchWriter(os.Stdout, conf.format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	gen, err := conf.generator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	in := io.Reader(os.Stdin)
+	if fs.NArg() == 1 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		defer f.Close()
+		in = f
+	}
+
+	records := make(chan batchRecord, conf.workers)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(records)
+		if conf.column == "" {
+			readErr <- readLines(in, records)
+		} else {
+			readErr <- readCSVColumn(in, conf, records)
+		}
+	}()
+
+	failed, err := processBatch(gen, records, conf.workers, write)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := <-readErr; err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d line(s) failed\n", failed)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// processBatch generates the IDs of records with workers in parallel
+// and writes the results in input order. Record errors are printed
+// to stderr and counted, only write errors stop the batch.
+func processBatch(gen *hashid.Generator, records <-chan batchRecord, workers int, write func(batchResult) error) (int, error) {
+	// each record gets its own result channel, queued in input order,
+	// so the writer can emit results in order while workers run in parallel
+	pending := make(chan chan batchResult, workers*4)
+	work := make(chan func(), workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for fn := range work {
+				fn()
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(work)
+		for record := range records {
+			record := record
+			result := make(chan batchResult, 1)
+			pending <- result
+			work <- func() {
+				r := batchResult{batchRecord: record}
+				if r.err == nil {
+					r.id, r.err = gen.New(record.input)
+				}
+				result <- r
+			}
+		}
+	}()
+
+	failed := 0
+	for result := range pending {
+		r := <-result
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "Error: line %d: %v\n", r.line, r.err)
+		}
+		if err := write(r); err != nil {
+			// drain the queue so the reader and workers can finish
+			go func() {
+				for result := range pending {
+					<-result
+				}
+			}()
+			return failed, err
+		}
+	}
+	return failed, nil
+}
+
+// maxLineSize is the longest input line, longer lines are
+// reported as failed without stopping the batch
+const maxLineSize = 1024 * 1024
+
+func readLines(r io.Reader, records chan<- batchRecord) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+
+	var buf []byte
+	line, tooLong := 0, false
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(buf)+len(chunk) > maxLineSize {
+			tooLong = true
+		}
+		if !tooLong {
+			buf = append(buf, chunk...)
+		}
+		if isPrefix {
+			continue
+		}
+
+		line++
+		text := strings.TrimRight(string(buf), "\r")
+		switch {
+		case tooLong:
+			records <- batchRecord{line: line, err: fmt.Errorf("line is longer than %d bytes", maxLineSize)}
+		case text != "":
+			records <- batchRecord{line: line, input: text}
+		}
+		buf, tooLong = buf[:0], false
+	}
+}
+
+func readCSVColumn(r io.Reader, conf batchConfig, records chan<- batchRecord) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	delimiter := []rune(conf.delimiter)
+	if len(delimiter) != 1 {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+	reader.Comma = delimiter[0]
+
+	column := -1
+	if index, err := strconv.Atoi(conf.column); err == nil {
+		if index < 1 {
+			return fmt.Errorf("column index must be 1 or greater")
+		}
+		column = index - 1
+	}
+
+	if column < 0 || conf.header {
+		header, err := reader.Read()
+		if err != nil {
+			return fmt.Errorf("failed to read CSV header: %w", err)
+		}
+
+		for i, name := range header {
+			if column < 0 && strings.TrimSpace(name) == conf.column {
+				column = i
+			}
+		}
+
+		if column < 0 {
+			return fmt.Errorf("column %q not found in CSV header", conf.column)
+		}
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		// quoted fields may span lines, report the line the
+		// value starts on instead of the record count
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			records <- batchRecord{line: parseErr.Line, err: parseErr.Err}
+		case err != nil:
+			return fmt.Errorf("failed to read CSV: %w", err)
+		case column >= len(row):
+			line, _ := reader.FieldPos(0)
+			records <- batchRecord{line: line, err: fmt.Errorf("column %s not found", conf.column)}
+		default:
+			line, _ := reader.FieldPos(column)
+			records <- batchRecord{line: line, input: row[column]}
+		}
+	}
+}
+
+func newBatchWriter(w io.Writer, format string) (func(batchResult) error, func() error, error) {
+	switch strings.ToLower(format) {
+	case "tsv":
+		bw := bufio.NewWriter(w)
+		escape := strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+		write := func(r batchResult) error {
+			_, err := fmt.Fprintf(bw, "%s\t%s\n", escape.Replace(r.input), r.id)
+			return err
+		}
+		return write, bw.Flush, nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		write := func(r batchResult) error {
+			return cw.Write([]string{r.input, r.id})
+		}
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		return write, flush, nil
+	case "jsonl":
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		enc.SetEscapeHTML(false)
+		write := func(r batchResult) error {
+			out := struct {
+				Line  int    `json:"line"`
+				Input string `json:"input"`
+				ID    string `json:"id,omitempty"`
+				Error string `json:"error,omitempty"`
+			}{Line: r.line, Input: r.input, ID: r.id}
+			if r.err != nil {
+				out.Error = r.err.Error()
+			}
+			return enc.Encode(out)
+		}
+		return write, bw.Flush, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
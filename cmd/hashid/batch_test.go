@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLines(t *testing.T) {
+	input := "first\r\n\n" + strings.Repeat("x", maxLineSize+1) + "\nlast"
+
+	records := make(chan batchRecord, 10)
+	require.NoError(t, readLines(strings.NewReader(input), records))
+	close(records)
+
+	var got []batchRecord
+	for record := range records {
+		got = append(got, record)
+	}
+
+	require.Len(t, got, 3)
+	assert.Equal(t, batchRecord{line: 1, input: "first"}, got[0])
+	assert.Equal(t, 3, got[1].line)
+	assert.Error(t, got[1].err, "oversized line")
+	assert.Equal(t, batchRecord{line: 4, input: "last"}, got[2])
+}
+
+func TestReadCSVColumn(t *testing.T) {
+	input := "name,email\n\"multi\nline\",a@example.com\nshort\n\"x\"y,b@example.com\nlast,c@example.com\n"
+
+	records := make(chan batchRecord, 10)
+	conf := batchConfig{column: "email", delimiter: ","}
+	require.NoError(t, readCSVColumn(strings.NewReader(input), conf, records))
+	close(records)
+
+	var got []batchRecord
+	for record := range records {
+		got = append(got, record)
+	}
+
+	// lines are physical lines, not records
+	require.Len(t, got, 4)
+	assert.Equal(t, batchRecord{line: 3, input: "a@example.com"}, got[0])
+	assert.Equal(t, 4, got[1].line)
+	assert.Error(t, got[1].err, "missing column")
+	assert.Equal(t, 5, got[2].line)
+	assert.Error(t, got[2].err, "bare quote")
+	assert.Equal(t, batchRecord{line: 6, input: "c@example.com"}, got[3])
+}
+
+func TestProcessBatch(t *testing.T) {
+	gen, err := hashid.NewGenerator(hashid.WithEmailNormalization())
+	require.NoError(t, err)
+
+	const total = 500
+
+	records := make(chan batchRecord)
+	go func() {
+		defer close(records)
+		for i := 1; i <= total; i++ {
+			switch {
+			case i%50 == 0:
+				records <- batchRecord{line: i, err: fmt.Errorf("unreadable")}
+			case i%7 == 0:
+				records <- batchRecord{line: i, input: fmt.Sprintf("invalid %d", i)}
+			default:
+				records <- batchRecord{line: i, input: fmt.Sprintf("user%d@example.com", i)}
+			}
+		}
+	}()
+
+	var results []batchResult
+	failed, err := processBatch(gen, records, 8, func(r batchResult) error {
+		results = append(results, r)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, total)
+
+	expectedFailed := 0
+	for i, r := range results {
+		line := i + 1
+		assert.Equal(t, line, r.line, "results keep the input order")
+
+		if line%50 == 0 || line%7 == 0 {
+			expectedFailed++
+			assert.Error(t, r.err, "line %d", line)
+			assert.Empty(t, r.id, "line %d", line)
+			continue
+		}
+
+		require.NoError(t, r.err, "line %d", line)
+		id, err := gen.New(r.input)
+		require.NoError(t, err)
+		assert.Equal(t, id, r.id, "line %d", line)
+	}
+	assert.Equal(t, expectedFailed, failed)
+}
+
+func TestProcessBatchWriteError(t *testing.T) {
+	gen, err := hashid.NewGenerator()
+	require.NoError(t, err)
+
+	records := make(chan batchRecord)
+	go func() {
+		defer close(records)
+		for i := 1; i <= 100; i++ {
+			records <- batchRecord{line: i, input: fmt.Sprintf("input %d", i)}
+		}
+	}()
+
+	_, err = processBatch(gen, records, 4, func(r batchResult) error {
+		if r.line == 10 {
+			return fmt.Errorf("disk full")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "disk full")
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+)
+
+func runCharmap(args []string) int {
+	if len(args) < 1 {
+		charmapUsage(os.Stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "show":
+		return charmapShow(args[1:])
+	case "lookup":
+		return charmapLookup(args[1:])
+	case "diff":
+		return charmapDiff(args[1:])
+	case "validate":
+		return charmapValidate(args[1:])
+	case "-h", "-help", "--help", "help":
+		charmapUsage(os.Stdout)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Unknown charmap command: %s\n\n", args[0])
+		charmapUsage(os.Stderr)
+		return exitUsage
+	}
+}
+
+// charmapShow prints the default or a custom charmap as JSON
+func charmapShow(args []string) int {
+	fs := flag.NewFlagSet("charmap show", flag.ExitOnError)
+	file := fs.String("charmap", "", "Path to custom character mapping JSON file")
+	fs.Parse(args)
+
+	mapping, err := resolveCharMap(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(mapping); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// charmapLookup prints the mapping of each character of the input.
+// Characters can also be given as code points, e.g. U+00A9.
+func charmapLookup(args []string) int {
+	fs := flag.NewFlagSet("charmap lookup", flag.ExitOnError)
+	file := fs.String("charmap", "", "Path to custom character mapping JSON file")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Error: At least one character is required")
+		return exitUsage
+	}
+
+	mapping, err := resolveCharMap(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	for _, arg := range fs.Args() {
+		chars, err := parseLookupArg(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+
+		for _, ch := range chars {
+			value, ok := mapping[string(ch)]
+			if !ok {
+				fmt.Printf("%s\tU+%04X\t(not mapped)\n", string(ch), ch)
+				continue
+			}
+			fmt.Printf("%s\tU+%04X\t%q\n", string(ch), ch, value)
+		}
+	}
+	return exitOK
+}
+
+// charmapDiff compares a custom charmap against the default one
+func charmapDiff(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Error: A charmap file is required")
+		return exitUsage
+	}
+
+	custom, err := loadCustomCharMap(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	defaults, err := hashid.GetCharMap()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	writeCharmapDiff(os.Stdout, defaults, custom)
+	return exitOK
+}
+
+// charmapValidate checks that a charmap file can be used
+func charmapValidate(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Error: A charmap file is required")
+		return exitUsage
+	}
+
+	mapping, err := loadCustomCharMap(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := hashid.ValidateCharMap(mapping); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", args[0], err)
+		return exitFailure
+	}
+
+	fmt.Printf("%s: OK (%d entries)\n", args[0], len(mapping))
+	return exitOK
+}
+
+func writeCharmapDiff(w io.Writer, defaults, custom map[string]string) {
+	keys := make([]string, 0, len(defaults)+len(custom))
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+	for k := range custom {
+		if _, ok := defaults[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		d, inDefault := defaults[k]
+		c, inCustom := custom[k]
+		switch {
+		case !inDefault:
+			fmt.Fprintf(w, "+ %s\t%q\n", k, c)
+		case !inCustom:
+			fmt.Fprintf(w, "- %s\t%q\n", k, d)
+		case d != c:
+			fmt.Fprintf(w, "~ %s\t%q -> %q\n", k, d, c)
+		}
+	}
+}
+
+func resolveCharMap(file string) (map[string]string, error) {
+	if file == "" {
+		return hashid.GetCharMap()
+	}
+	return loadCustomCharMap(file)
+}
+
+func parseLookupArg(arg string) ([]rune, error) {
+	if !strings.HasPrefix(strings.ToUpper(arg), "U+") {
+		return []rune(arg), nil
+	}
+
+	cp, err := strconv.ParseUint(arg[2:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code point %s: %w", arg, err)
+	}
+	return []rune{rune(cp)}, nil
+}
+
+func charmapUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage: hashid charmap <command> [arguments]
+
+Commands:
+  show [-charmap file]               Print the default (or custom) charmap as JSON
+  lookup [-charmap file] <chars>...  Print the mapping of each character, accepts U+XXXX
+  diff <file>                        Compare a custom charmap against the default
+  validate <file>                    Check that a charmap file can be used
+
+Examples:
+  hashid charmap show
+  hashid charmap lookup © U+20B9
+  hashid charmap diff custom.json
+  hashid charmap validate custom.json
+
+`)
+}
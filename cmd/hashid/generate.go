@@ -0,0 +1,383 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+	"github.com/goliatone/hashid/pkg/version"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+type config struct {
+	algorithm   string
+	hmacKey     string
+	keyFile     string
+	keyEnv      string
+	hkdfInfo    string
+	noNormalize bool
+	uuidVersion int
+	showVersion bool
+	charmapFile string
+	pipeline    string
+	normalizer  string
+	caseFolding string
+	locale      string
+	namespace   string
+	encoding    string
+	parts       partsFlag
+
+	embedFingerprint bool
+	strict           bool
+	legacyVersions   bool
+}
+
+// legacyAlgorithms are the algorithms of earlier releases,
+// whose IDs keep version 3 by default
+var legacyAlgorithms = map[hashid.HashAlgorithm]bool{
+	hashid.MD5:         true,
+	hashid.SHA1:        true,
+	hashid.SHA256:      true,
+	hashid.HMAC_SHA256: true,
+}
+
+// partsFlag collects repeated -part flags
+type partsFlag []string
+
+func (p *partsFlag) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *partsFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// registerFlags adds the flags used to configure ID generation
+func (c *config) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.algorithm, "hash", string(hashid.MD5), "Hashing algorithm, e.g. md5, sha1, sha256, hmac")
+	fs.StringVar(&c.hmacKey, "key", "", "HMAC key (required when using hmac algorithm)")
+	fs.StringVar(&c.keyFile, "key-file", "", "Read the HMAC key from a file")
+	fs.StringVar(&c.keyEnv, "key-env", "", "Read the HMAC key from an environment variable")
+	fs.StringVar(&c.hkdfInfo, "hkdf-info", "", "Derive the HMAC key from the given key using HKDF with this context")
+	fs.BoolVar(&c.noNormalize, "no-normalize", false, "Disable string normalization")
+	fs.IntVar(&c.uuidVersion, "uuid-version", 0, "Force specific UUID version (3, 5, or 8)")
+	fs.StringVar(&c.charmapFile, "charmap", "", "Path to custom character mapping JSON file")
+	fs.StringVar(&c.normalizer, "normalizer", "", "Named normalizer, e.g. email")
+	fs.StringVar(&c.pipeline, "pipeline", "", "Normalization pipeline spec, e.g. nfkc,trim,casefold")
+	fs.StringVar(&c.caseFolding, "case-folding", "", "Case folding mode (lower, simple, full)")
+	fs.StringVar(&c.locale, "locale", "", "Language tag whose case rules are applied, e.g. tr or de")
+	fs.StringVar(&c.namespace, "namespace", "", "Namespace UUID or one of dns, url, oid, x500")
+	fs.Var(&c.parts, "part", "Composite key part, can be repeated")
+	fs.StringVar(&c.encoding, "encoding", "", "Short ID encoding (base57, base57-padded, base58, base62, base36, crockford, human)")
+	fs.BoolVar(&c.strict, "strict", false, "Reject UUID versions that do not match the hashing algorithm")
+	fs.BoolVar(&c.legacyVersions, "legacy-versions", false, "Keep the UUID versions of earlier releases (version 3 for every algorithm)")
+	fs.BoolVar(&c.embedFingerprint, "embed-fingerprint", false, "Embed the configuration fingerprint in UUID v8 IDs")
+}
+
+// options translates the configuration to hashid options
+func (c *config) options() ([]hashid.Option, error) {
+	options := []hashid.Option{}
+
+	key, err := c.key()
+	if err != nil {
+		return nil, err
+	}
+
+	algo := hashid.HashAlgorithm(strings.ToLower(c.algorithm))
+	options = append(options, hashid.WithHashAlgorithm(algo))
+
+	// keyed algorithms report a missing key themselves, other
+	// algorithms ignore the key as earlier releases did
+	if key != nil {
+		if algo.Keyed() {
+			options = append(options, hashid.WithHMACKey(key))
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: the key is ignored by %s, use a keyed -hash such as hmac\n", algo)
+		}
+	}
+
+	if c.noNormalize {
+		options = append(options, hashid.WithNormalization(false))
+	}
+
+	if c.charmapFile != "" {
+		mapping, err := loadCustomCharMap(c.charmapFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := hashid.ValidateCharMap(mapping); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.charmapFile, err)
+		}
+		options = append(options, hashid.WithCustomCharMap(mapping))
+	}
+
+	if c.normalizer != "" {
+		if c.charmapFile != "" || c.pipeline != "" {
+			return nil, fmt.Errorf("-normalizer cannot be combined with -charmap or -pipeline")
+		}
+		normalizer, err := hashid.LookupNormalizer(c.normalizer)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hashid.WithCustomNormalizer(normalizer))
+	}
+
+	if c.pipeline != "" {
+		if c.charmapFile != "" {
+			return nil, fmt.Errorf("-charmap and -pipeline cannot be combined")
+		}
+		p, err := hashid.ParsePipeline(c.pipeline)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hashid.WithPipeline(p))
+	}
+
+	if c.caseFolding != "" || c.locale != "" {
+		if c.normalizer != "" || c.pipeline != "" {
+			return nil, fmt.Errorf("-case-folding and -locale cannot be combined with -normalizer or -pipeline")
+		}
+		caseOptions, err := caseFoldingOptions(c.caseFolding, c.locale)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, caseOptions...)
+	}
+
+	switch c.uuidVersion {
+	case 3, 5, 8:
+		options = append(options, hashid.WithUUIDVersion(c.uuidVersion))
+	case 0:
+		// earlier releases stamped version 3 on every ID, namespaced
+		// IDs and newer algorithms let the algorithm pick the version.
+		// Legacy mode accepts version 3 for any algorithm.
+		if c.legacyVersions || (c.namespace == "" && legacyAlgorithms[algo]) {
+			options = append(options, hashid.WithUUIDVersion(3), hashid.WithLegacyVersions())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported UUID version: %d", c.uuidVersion)
+	}
+
+	if c.namespace != "" {
+		ns, err := parseNamespace(c.namespace)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hashid.WithNamespace(ns))
+	}
+
+	if c.embedFingerprint {
+		options = append(options, hashid.WithEmbeddedFingerprint())
+	}
+
+	if c.encoding != "" {
+		enc, err := hashid.LookupEncoding(c.encoding)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hashid.WithShortEncoding(enc))
+	}
+
+	if c.strict {
+		options = append(options, hashid.WithStrictVersion())
+	}
+
+	if c.legacyVersions {
+		options = append(options, hashid.WithLegacyVersions())
+	}
+
+	return options, nil
+}
+
+// caseFoldingOptions translates the -case-folding and -locale flags
+func caseFoldingOptions(mode, locale string) ([]hashid.Option, error) {
+	var options []hashid.Option
+
+	if mode != "" {
+		caseFolding, err := hashid.ParseCaseFolding(mode)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hashid.WithCaseFolding(caseFolding))
+	}
+
+	if locale != "" {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid locale %q: %w", locale, err)
+		}
+		options = append(options, hashid.WithLocale(tag))
+	}
+
+	return options, nil
+}
+
+// generator creates the generator for the configuration,
+// configuration warnings are printed to stderr
+func (c *config) generator() (*hashid.Generator, error) {
+	options, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := hashid.NewGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warning := range gen.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
+	}
+	return gen, nil
+}
+
+// key resolves the HMAC key from -key, -key-file or -key-env,
+// it returns nil when no key was given
+func (c *config) key() ([]byte, error) {
+	var (
+		key     []byte
+		err     error
+		sources int
+	)
+
+	if c.hmacKey != "" {
+		sources++
+		key, err = hashid.ParseKey(c.hmacKey)
+	}
+
+	if c.keyFile != "" {
+		sources++
+		key, err = hashid.KeyFromFile(c.keyFile)
+	}
+
+	if c.keyEnv != "" {
+		sources++
+		key, err = hashid.KeyFromEnv(c.keyEnv)
+	}
+
+	if sources > 1 {
+		return nil, fmt.Errorf("use only one of -key, -key-file or -key-env")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.hkdfInfo != "" {
+		if key == nil {
+			return nil, fmt.Errorf("-hkdf-info requires a key")
+		}
+		key = hashid.DeriveKey(key, []byte(c.hkdfInfo))
+	}
+
+	return key, nil
+}
+
+// runGenerate prints the UUID generated from the input
+func runGenerate(name string, args []string, usage func()) int {
+	return generate(name, args, usage, false)
+}
+
+// runShort prints the short ID generated from the input
+func runShort(args []string) int {
+	return generate("short", args, shortUsage, true)
+}
+
+func generate(name string, args []string, usage func(), short bool) int {
+	conf := config{}
+	output := ""
+
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	conf.registerFlags(fs)
+	fs.BoolVar(&conf.showVersion, "version", false, "Show version information")
+	fs.StringVar(&output, "output", "text", "Output format (text, json, yaml)")
+	fs.Usage = usage
+	fs.Parse(args)
+
+	if conf.showVersion {
+		version.Print(os.Stdout)
+		return exitOK
+	}
+
+	if fs.NArg() < 1 && len(conf.parts) == 0 {
+		fmt.Fprint(os.Stderr, "Error: Input string is required\n\n")
+		usage()
+		return exitUsage
+	}
+
+	if fs.NArg() > 0 && len(conf.parts) > 0 {
+		fmt.Fprint(os.Stderr, "Error: Use either an input string or -part flags, not both\n\n")
+		usage()
+		return exitUsage
+	}
+
+	write, err := newResultWriter(output, short)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		usage()
+		return exitUsage
+	}
+
+	gen, err := conf.generator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	res, err := newResult(gen, strings.Join(fs.Args(), " "), conf.parts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating UUID: %v\n", err)
+		return exitFailure
+	}
+
+	if err := write(os.Stdout, res); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// lookupEncoding returns the named short ID encoding,
+// or the default one when name is empty
+func lookupEncoding(name string) (hashid.Encoding, error) {
+	if name == "" {
+		return hashid.Base57, nil
+	}
+	return hashid.LookupEncoding(name)
+}
+
+func parseNamespace(value string) (uuid.UUID, error) {
+	switch strings.ToLower(value) {
+	case "dns":
+		return hashid.NamespaceDNS, nil
+	case "url":
+		return hashid.NamespaceURL, nil
+	case "oid":
+		return hashid.NamespaceOID, nil
+	case "x500":
+		return hashid.NamespaceX500, nil
+	}
+
+	ns, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid namespace %q: %w", value, err)
+	}
+	return ns, nil
+}
+
+func loadCustomCharMap(filename string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read charmap file: %w", err)
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse charmap file: %w", err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/sha512"
+	"flag"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateID runs the generate flags and returns the UUID of the input
+func generateID(t *testing.T, args ...string) string {
+	t.Helper()
+
+	conf := config{}
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	conf.registerFlags(fs)
+	require.NoError(t, fs.Parse(args))
+
+	gen, err := conf.generator()
+	require.NoError(t, err)
+
+	id, err := gen.New(strings.Join(fs.Args(), " "))
+	require.NoError(t, err)
+	return id
+}
+
+func TestLegacyVersionsFlag(t *testing.T) {
+	// IDs printed by the CLI before versions were derived from the algorithm
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-hash", "md5"}, "a81e9c73-460c-3e9d-a247-1e2bcd20e7d9"},
+		{[]string{"-hash", "sha1"}, "df6cdaa0-6600-3dd3-92eb-7ce39d603342"},
+		{[]string{"-hash", "sha256"}, "65f989fe-a23b-34e6-acca-4c3199e962a5"},
+		{[]string{"-hash", "hmac", "-key", "mysecret"}, "f62d2458-45ae-3bb5-8771-25c31f969ba8"},
+		{[]string{"-hash", "sha1", "-uuid-version", "5"}, "df6cdaa0-6600-5dd3-92eb-7ce39d603342"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			args := append([]string{"-legacy-versions"}, tc.args...)
+			assert.Equal(t, tc.expected, generateID(t, append(args, "User@Example.com")...))
+		})
+	}
+}
+
+func TestDefaultVersions(t *testing.T) {
+	// without -uuid-version the CLI keeps printing the IDs of earlier releases
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, "a81e9c73-460c-3e9d-a247-1e2bcd20e7d9"},
+		{[]string{"-hash", "sha1"}, "df6cdaa0-6600-3dd3-92eb-7ce39d603342"},
+		{[]string{"-hash", "sha256"}, "65f989fe-a23b-34e6-acca-4c3199e962a5"},
+		{[]string{"-hash", "hmac", "-key", "mysecret"}, "f62d2458-45ae-3bb5-8771-25c31f969ba8"},
+		{[]string{"-hash", "sha1", "-uuid-version", "5"}, "df6cdaa0-6600-5dd3-92eb-7ce39d603342"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			assert.Equal(t, tc.expected, generateID(t, append(tc.args, "User@Example.com")...))
+		})
+	}
+
+	// namespaced IDs use the version of the algorithm
+	assert.Equal(t, "5", generateID(t, "-namespace", "dns", "-hash", "sha1", "example.com")[14:15])
+	assert.Equal(t, "3", generateID(t, "-namespace", "dns", "example.com")[14:15])
+}
+
+func TestKeyWithoutKeyedAlgorithm(t *testing.T) {
+	// the key is ignored and MD5 stays the default, as in earlier releases
+	assert.Equal(t, "a81e9c73-460c-3e9d-a247-1e2bcd20e7d9", generateID(t, "-key", "mysecret", "User@Example.com"))
+	assert.Equal(t, generateID(t, "-hash", "sha1", "User@Example.com"), generateID(t, "-hash", "sha1", "-key", "mysecret", "User@Example.com"))
+
+	// registered algorithms only get the key when they are keyed
+	require.NoError(t, hashid.RegisterAlgorithm("test-cli-unkeyed", func(key []byte) (hash.Hash, error) {
+		return sha512.New(), nil
+	}, 8))
+	assert.Equal(t, generateID(t, "-hash", "test-cli-unkeyed", "User@Example.com"), generateID(t, "-hash", "test-cli-unkeyed", "-key", "mysecret", "User@Example.com"))
+}
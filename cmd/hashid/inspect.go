@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+)
+
+// runParse prints the canonical UUID of each short ID
+func runParse(args []string) int {
+	encoding := ""
+
+	fs := flag.NewFlagSet("parse", flag.ExitOnError)
+	fs.StringVar(&encoding, "encoding", "", "Short ID encoding (base57, base57-padded, base58, base62, base36, crockford, human)")
+	fs.Usage = parseUsage
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Fprint(os.Stderr, "Error: Short ID is required\n\n")
+		parseUsage()
+		return exitUsage
+	}
+
+	enc, err := lookupEncoding(encoding)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	for _, sid := range fs.Args() {
+		uid, err := hashid.ParseShortID(sid, hashid.WithShortEncoding(enc))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing short ID %q: %v\n", sid, err)
+			code = exitFailure
+			continue
+		}
+		fmt.Println(uid)
+	}
+	return code
+}
+
+// runInspect prints version, variant and algorithm hints
+// of a UUID or short ID. When -embed-fingerprint is set the
+// generation options describe a profile to match against.
+func runInspect(args []string) int {
+	conf := config{}
+
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	conf.registerFlags(fs)
+	fs.Usage = inspectUsage
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, "Error: A single UUID or short ID is required\n\n")
+		inspectUsage()
+		return exitUsage
+	}
+
+	profiles := []*hashid.Generator{}
+	if conf.embedFingerprint {
+		gen, err := conf.generator()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		profiles = append(profiles, gen)
+	}
+
+	enc, err := lookupEncoding(conf.encoding)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	uid, err := hashid.ParseID(fs.Arg(0), hashid.WithShortEncoding(enc))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	info := hashid.InspectUUID(uid, profiles...)
+
+	fmt.Printf("UUID:       %s\n", info.UUID)
+	fmt.Printf("Version:    %d\n", info.Version)
+	fmt.Printf("Variant:    %s\n", info.Variant)
+	fmt.Printf("Conforms:   %s\n", yesNo(info.Conforms))
+	fmt.Printf("Algorithms: %s\n", strings.Join(algorithmHints(info), ", "))
+
+	for _, gen := range profiles {
+		match := "no match"
+		if len(info.Profiles) > 0 {
+			match = "match"
+		}
+		fmt.Printf("Profile:    %s (%s)\n", gen.Fingerprint(), match)
+	}
+	return exitOK
+}
+
+// algorithmHints lists the algorithms that could have
+// produced the UUID based on its version bits
+func algorithmHints(info hashid.Inspection) []string {
+	if len(info.Algorithms) == 0 {
+		return []string{"none (not generated by hashid)"}
+	}
+
+	hints := make([]string, 0, len(info.Algorithms))
+	for _, algo := range info.Algorithms {
+		hints = append(hints, string(algo))
+	}
+	return hints
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
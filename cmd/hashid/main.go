@@ -1,138 +1,292 @@
 package main
 
 import (
-	"encoding/json"
-	"flag"
 	"fmt"
 	"os"
-	"strings"
 
-	"github.com/goliatone/hashid/pkg/hashid"
 	"github.com/goliatone/hashid/pkg/version"
 )
 
-type config struct {
-	algorithm   string
-	hmacKey     string
-	noNormalize bool
-	uuidVersion int
-	showVersion bool
-	charmapFile string
-}
+// exit codes
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
 
 func main() {
-	conf := parseFlags()
-
-	if conf.showVersion {
-		version.Print(os.Stdout)
-		os.Exit(0)
-	}
+	os.Exit(run(os.Args[1:]))
+}
 
-	if len(flag.Args()) < 1 {
+func run(args []string) int {
+	if len(args) == 0 {
 		fmt.Fprint(os.Stderr, "Error: Input string is required\n\n")
 		usage()
-		os.Exit(1)
-	}
-
-	input := strings.Join(flag.Args(), " ")
-
-	options := []hashid.Option{}
-
-	switch strings.ToLower(conf.algorithm) {
-	case "md5":
-		options = append(options, hashid.WithHashAlgorithm(hashid.MD5))
-	case "sha1":
-		options = append(options, hashid.WithHashAlgorithm(hashid.SHA1))
-	case "sha256":
-		options = append(options, hashid.WithHashAlgorithm(hashid.SHA256))
-	case "hmac":
-		if conf.hmacKey == "" {
-			fmt.Fprintln(os.Stderr, "Error: HMAC key is required when using HMAC algorithm")
-			os.Exit(1)
-		}
-		options = append(options,
-			hashid.WithHashAlgorithm(hashid.HMAC_SHA256),
-			hashid.WithHMACKey([]byte(conf.hmacKey)))
-	default:
-		fmt.Fprintf(os.Stderr, "Error: Unsupported hashing algorithm: %s\n", conf.algorithm)
-		os.Exit(1)
-	}
-
-	if conf.noNormalize {
-		options = append(options, hashid.WithNormalization(false))
+		return exitUsage
 	}
 
-	switch conf.uuidVersion {
-	case 3, 5, 8:
-		options = append(options, hashid.WithUUIDVersion(conf.uuidVersion))
-	case 0:
-		options = append(options, hashid.WithUUIDVersion(3))
+	switch args[0] {
+	case "generate":
+		return runGenerate("generate", args[1:], generateUsage)
+	case "short":
+		return runShort(args[1:])
+	case "parse":
+		return runParse(args[1:])
+	case "inspect":
+		return runInspect(args[1:])
+	case "normalize":
+		return runNormalize(args[1:])
+	case "verify":
+		return runVerify(args[1:])
+	case "batch":
+		return runBatch(args[1:])
+	case "charmap":
+		return runCharmap(args[1:])
+	case "help":
+		usage()
+		return exitOK
 	default:
-		fmt.Printf("Unsupported UUID version: %d\n", conf.uuidVersion)
-		os.Exit(1)
-	}
-
-	uuid, err := hashid.New(input, options...)
-	if err != nil {
-		fmt.Printf("Error generating UUID: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Println(uuid)
-}
-
-func parseFlags() config {
-	conf := config{}
-
-	flag.StringVar(&conf.algorithm, "hash", "md5", "Hashing algorithm (md5, sha1, sha256, hmac)")
-	flag.StringVar(&conf.hmacKey, "key", "", "HMAC key (required when using hmac algorithm)")
-	flag.BoolVar(&conf.noNormalize, "no-normalize", false, "Disable string normalization")
-	flag.IntVar(&conf.uuidVersion, "uuid-version", 0, "Force specific UUID version (3, 5, or 8)")
-	flag.BoolVar(&conf.showVersion, "version", false, "Show version information")
-	flag.StringVar(&conf.charmapFile, "charmap", "", "Path to custom character mapping JSON file")
-
-	flag.Usage = usage
-
-	flag.Parse()
-	return conf
-}
-
-func loadCustomCharMap(filename string) (map[string]string, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("filed to read charmap file: %w", err)
-	}
-	var mapping map[string]string
-	if err := json.Unmarshal(data, &mapping); err != nil {
-		return nil, fmt.Errorf("filed to parse charmap file: %w", err)
+		// without a command we keep the original behavior
+		return runGenerate("hashid", args, usage)
 	}
-	return mapping, nil
 }
 
-func usage() {
-	fmt.Fprintf(os.Stderr, `Usage: hashid [options] <user@example.com>
-
-Options:
+const generateOptions = `Options:
+  -case-folding string
+        Case folding mode: lower (default), simple or full Unicode case folding,
+        e.g. full folding maps "ﬁ" to "fi"
+  -charmap string
+        Path to custom character mapping JSON file
+  -embed-fingerprint
+        Embed the configuration fingerprint in the last 16 bits of UUID v8 IDs
+  -encoding string
+        Short ID encoding: base57, base57-padded, base58, base62, base36,
+        crockford or human
+        (default "base57")
   -hash string
-        Hashing algorithm (md5, sha1, sha256, hmac) (default "md5")
+        Hashing algorithm: md5, sha1, sha256, sha512, sha3-256, blake2b, blake3,
+        hmac (HMAC-SHA256), hmac-sha512, hmac-sha3-256, hmac-blake2b, hmac-blake3,
+        or any algorithm registered with hashid.RegisterAlgorithm
+        (default "md5")
+  -hkdf-info string
+        Derive the HMAC key from the given key using HKDF-SHA256 with this context
   -key string
-        HMAC key (required when using hmac algorithm)
+        HMAC key (required when using hmac algorithm), prefix with hex: or
+        base64: to decode it
+  -key-env string
+        Read the HMAC key from an environment variable
+  -key-file string
+        Read the HMAC key from a file
+  -legacy-versions
+        Keep the UUID versions of earlier releases: version 3 for every
+        algorithm unless -uuid-version is set
+  -locale string
+        Language tag whose case rules are applied, e.g. tr for the Turkish
+        dotted and dotless i, which the default charmap already maps to i
+  -namespace string
+        Namespace UUID or one of dns, url, oid, x500
   -no-normalize
         Disable string normalization
+  -normalizer string
+        Named normalizer: default, none, email, phone (international
+        numbers), url, domain, or one registered with hashid.RegisterNormalizer
+  -part value
+        Composite key part, can be repeated
+  -pipeline string
+        Normalization pipeline spec: comma separated steps nfc, nfd, nfkc, nfkd,
+        charmap[:"sep"], strip:"chars", trim, collapse:"sep",
+        casefold[:"mode"[:"locale"]], phone[:"region"], or normalizers
+        registered with hashid.RegisterNormalizer
+  -strict
+        Reject UUID versions that do not match the hashing algorithm
   -uuid-version int
-        Force specific UUID version (3, 5, or 8) (default 3)
+        Force specific UUID version (3, 5, or 8). Without it md5, sha1,
+        sha256 and hmac IDs keep version 3 as in earlier releases, other
+        algorithms and -namespace use the version of the algorithm
+`
+
+const outputOptions = `  -output string
+        Output format (text, json, yaml) (default "text")
   -version
         Show version information
+`
 
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid [options] <user@example.com>
+       hashid [options] -part <value> [-part <value>...]
+       hashid <command> [arguments]
+
+Commands:
+  generate    Generate a UUID from the input (default)
+  short       Generate a short ID from the input
+  parse       Convert a short ID to its canonical UUID
+  inspect     Print version, variant, algorithm hints and profile of a UUID
+  normalize   Print the normalized input
+  verify      Check whether an ID was generated from the input
+  batch       Generate UUIDs for each line or CSV column value of a file
+  charmap     Manage character maps (show, lookup, diff, validate)
+
+%s%s
 Examples:
   hashid "user@example.com"
   hashid -hash sha1 "user@example.com"
   hashid -hash hmac -key mysecret "user@example.com"
+  hashid -hash hmac -key-env HASHID_KEY "user@example.com"
+  hashid -hash hmac -key-file /run/secrets/hashid -hkdf-info billing "user@example.com"
+  hashid -hash blake3 "user@example.com"
   hashid -no-normalize "user@example.com"
-  hashid -normalize upper "user@example.com"
+  hashid -normalizer email "J.Doe+news@GoogleMail.com"
   hashid -uuid-version 8 "user@example.com"
+  hashid -hash sha1 -namespace dns -no-normalize "www.example.com"
+  hashid -part acme -part order -part 1234
+  hashid -charmap custom.json "user@example.com"
+  hashid short "user@example.com"
+  hashid parse 2M9JFnemic9bLiXnT8AHun
+  hashid inspect ddea575a-d5e2-3114-9267-dbead79c4ab8
+  hashid normalize "User@Example.com"
+  hashid verify "user@example.com" ddea575a-d5e2-3114-9267-dbead79c4ab8
+  hashid batch -column email -format csv users.csv
+  hashid -output json "user@example.com"
+  hashid charmap lookup ©
+
+Exit codes:
+  0  success
+  1  generation or runtime error
+  2  invalid usage
 
 Version:
   %s
 
-`, version.GetVersion())
+`, generateOptions, outputOptions, version.GetVersion())
+}
+
+func generateUsage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid generate [options] <user@example.com>
+       hashid generate [options] -part <value> [-part <value>...]
+
+%s%s
+Examples:
+  hashid generate "user@example.com"
+  hashid generate -hash sha1 -namespace dns -no-normalize "www.example.com"
+  hashid generate -output yaml "user@example.com"
+
+`, generateOptions, outputOptions)
+}
+
+func shortUsage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid short [options] <user@example.com>
+       hashid short [options] -part <value> [-part <value>...]
+
+%s%s
+Examples:
+  hashid short "user@example.com"
+  hashid short -hash sha1 "user@example.com"
+  hashid short -encoding crockford "user@example.com"
+  hashid short -encoding human "user@example.com"
+
+`, generateOptions, outputOptions)
+}
+
+func batchUsage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid batch [options] [file]
+
+Reads newline delimited input, or a CSV column when -column is set,
+from file or stdin and writes input/UUID pairs in input order.
+Lines that fail are reported on stderr without stopping the batch.
+
+%s  -column string
+        CSV column to hash, by header name or 1-based index
+  -delimiter string
+        CSV field delimiter (default ",")
+  -format string
+        Output format (tsv, csv, jsonl) (default "tsv")
+  -header
+        Skip the CSV header row when -column is an index
+  -workers int
+        Number of parallel workers (default number of CPUs)
+
+Examples:
+  cat emails.txt | hashid batch
+  hashid batch -hash sha1 -format jsonl emails.txt
+  hashid batch -column email -format csv users.csv
+  hashid batch -column 2 -header users.csv
+
+`, generateOptions)
+}
+
+func parseUsage() {
+	fmt.Fprint(os.Stderr, `Usage: hashid parse [options] <short-id>...
+
+Options:
+  -encoding string
+        Short ID encoding: base57, base57-padded, base58, base62, base36,
+        crockford or human
+        (default "base57")
+
+Examples:
+  hashid parse 2M9JFnemic9bLiXnT8AHun
+  hashid parse -encoding base62 57EhPnopyjGlByaRL0GcNF
+
+`)
+}
+
+func inspectUsage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid inspect [options] <uuid|short-id>
+
+With -embed-fingerprint the options describe a profile, and
+inspect reports whether the ID carries its fingerprint.
+
+%s
+Examples:
+  hashid inspect ddea575a-d5e2-3114-9267-dbead79c4ab8
+  hashid inspect 2M9JFnemic9bLiXnT8AHun
+  hashid inspect -hash blake3 -embed-fingerprint 6437b3ac-3846-8133-bfb6-3b75273ae693
+
+`, generateOptions)
+}
+
+func verifyUsage() {
+	fmt.Fprintf(os.Stderr, `Usage: hashid verify [options] <input> <uuid|short-id>
+
+Prints OK and exits with 0 when the ID was generated from the input
+with the given options, prints MISMATCH and exits with 1 otherwise.
+
+%s  -quiet
+        Only report the result through the exit code
+
+Examples:
+  hashid verify "user@example.com" ddea575a-d5e2-3114-9267-dbead79c4ab8
+  hashid verify -hash hmac -key-env HASHID_KEY "user@example.com" 2M9JFnemic9bLiXnT8AHun
+
+`, generateOptions)
+}
+
+func normalizeUsage() {
+	fmt.Fprint(os.Stderr, `Usage: hashid normalize [options] <input>
+
+Options:
+  -case-folding string
+        Case folding mode: lower, simple or full
+  -charmap string
+        Path to custom character mapping JSON file
+  -locale string
+        Language tag whose case rules are applied, e.g. tr or de
+  -normalizer string
+        Named normalizer, e.g. email
+  -pipeline string
+        Normalization pipeline spec, see hashid -h
+  -separator string
+        Separator used to replace whitespace (default "-")
+
+Examples:
+  hashid normalize "User@Example.com"
+  hashid normalize -separator _ "Multiple   Spaces"
+  hashid normalize -normalizer email "J.Doe+news@GoogleMail.com"
+  hashid normalize -pipeline 'phone:"US"' "(555) 010-0000"
+  hashid normalize -normalizer url "HTTP://Example.COM:80/a/./b/../c"
+  hashid normalize -pipeline 'nfkc,trim,collapse:"_",casefold' "Multiple   Spaces"
+  hashid normalize -case-folding full "ﬁnance"
+  hashid normalize -pipeline 'nfc,trim,casefold:"full":"tr"' "ISPARTA Straße"
+
+`)
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+)
+
+// runNormalize prints the normalized input
+func runNormalize(args []string) int {
+	fs := flag.NewFlagSet("normalize", flag.ExitOnError)
+	separator := fs.String("separator", "-", "Separator used to replace whitespace")
+	charmapFile := fs.String("charmap", "", "Path to custom character mapping JSON file")
+	pipeline := fs.String("pipeline", "", "Normalization pipeline spec")
+	normalizer := fs.String("normalizer", "", "Named normalizer, e.g. email")
+	caseFolding := fs.String("case-folding", "", "Case folding mode (lower, simple, full)")
+	locale := fs.String("locale", "", "Language tag whose case rules are applied, e.g. tr or de")
+	fs.Usage = normalizeUsage
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Fprint(os.Stderr, "Error: Input string is required\n\n")
+		normalizeUsage()
+		return exitUsage
+	}
+
+	input := strings.Join(fs.Args(), " ")
+
+	var out string
+	var err error
+	caseFlags := *caseFolding != "" || *locale != ""
+
+	switch {
+	case caseFlags && (*normalizer != "" || *pipeline != "" || *separator != "-"):
+		err = fmt.Errorf("-case-folding and -locale cannot be combined with -normalizer, -pipeline or -separator")
+	case caseFlags:
+		out, err = normalizeCase(input, *caseFolding, *locale, *charmapFile)
+	case *normalizer != "" && (*pipeline != "" || *charmapFile != "" || *separator != "-"):
+		err = fmt.Errorf("-normalizer cannot be combined with -pipeline, -charmap or -separator")
+	case *normalizer != "":
+		var fn func(string) (string, error)
+		fn, err = hashid.LookupNormalizer(*normalizer)
+		if err == nil {
+			out, err = fn(input)
+		}
+	case *pipeline != "" && (*charmapFile != "" || *separator != "-"):
+		err = fmt.Errorf("-pipeline cannot be combined with -charmap or -separator")
+	case *pipeline != "":
+		var p hashid.Pipeline
+		p, err = hashid.ParsePipeline(*pipeline)
+		if err == nil {
+			out, err = p.Normalize(input)
+		}
+	case *charmapFile != "" && *separator != "-":
+		err = fmt.Errorf("-charmap and -separator cannot be combined")
+	case *charmapFile != "":
+		var mapping map[string]string
+		mapping, err = loadCustomCharMap(*charmapFile)
+		if err == nil {
+			out, err = hashid.NormalizerWithCharMap(input, mapping)
+		}
+	default:
+		out, err = hashid.NormalizerWithSeparator(input, *separator)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Println(out)
+	return exitOK
+}
+
+// normalizeCase applies the default normalizer, or the one built from
+// a custom charmap, with the given case folding mode and locale
+func normalizeCase(input, mode, locale, charmapFile string) (string, error) {
+	options, err := caseFoldingOptions(mode, locale)
+	if err != nil {
+		return "", err
+	}
+
+	if charmapFile != "" {
+		mapping, err := loadCustomCharMap(charmapFile)
+		if err != nil {
+			return "", err
+		}
+		options = append(options, hashid.WithCustomCharMap(mapping))
+	}
+
+	gen, err := hashid.NewGenerator(options...)
+	if err != nil {
+		return "", err
+	}
+	return gen.Normalize(input)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/goliatone/hashid/pkg/hashid"
+)
+
+// result holds everything we know about a generated ID
+type result struct {
+	Input           string   `json:"input,omitempty"`
+	Normalized      string   `json:"normalized,omitempty"`
+	Parts           []string `json:"parts,omitempty"`
+	NormalizedParts []string `json:"normalized_parts,omitempty"`
+	Algorithm       string   `json:"algorithm"`
+	Version         int      `json:"version"`
+	UUID            string   `json:"uuid"`
+	ShortID         string   `json:"short_id"`
+	Fingerprint     string   `json:"fingerprint"`
+}
+
+func newResult(gen *hashid.Generator, input string, parts []string) (result, error) {
+	res := result{
+		Algorithm:   string(gen.Algorithm()),
+		Version:     gen.Version(),
+		Fingerprint: gen.Fingerprint(),
+	}
+
+	var err error
+	if len(parts) > 0 {
+		res.Parts = parts
+		for _, part := range parts {
+			normalized, err := gen.Normalize(part)
+			if err != nil {
+				return res, err
+			}
+			res.NormalizedParts = append(res.NormalizedParts, normalized)
+		}
+
+		if res.UUID, err = gen.NewFromParts(parts); err != nil {
+			return res, err
+		}
+		if res.ShortID, err = gen.NewShortIDFromParts(parts); err != nil {
+			return res, err
+		}
+		return res, nil
+	}
+
+	res.Input = input
+	if res.Normalized, err = gen.Normalize(input); err != nil {
+		return res, err
+	}
+	if res.UUID, err = gen.New(input); err != nil {
+		return res, err
+	}
+	if res.ShortID, err = gen.NewShortID(input); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// newResultWriter returns a function that writes a result in the
+// given format. The text format only prints the ID, the short ID
+// if short is true.
+func newResultWriter(format string, short bool) (func(io.Writer, result) error, error) {
+	switch strings.ToLower(format) {
+	case "text", "":
+		return func(w io.Writer, res result) error {
+			id := res.UUID
+			if short {
+				id = res.ShortID
+			}
+			_, err := fmt.Fprintln(w, id)
+			return err
+		}, nil
+	case "json":
+		return func(w io.Writer, res result) error {
+			enc := json.NewEncoder(w)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			return enc.Encode(res)
+		}, nil
+	case "yaml":
+		return writeYAML, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+func writeYAML(w io.Writer, res result) error {
+	var b strings.Builder
+
+	field := func(key, value string) {
+		// Go quoted strings are valid YAML double quoted scalars
+		fmt.Fprintf(&b, "%s: %s\n", key, strconv.Quote(value))
+	}
+	list := func(key string, values []string) {
+		fmt.Fprintf(&b, "%s:\n", key)
+		for _, v := range values {
+			fmt.Fprintf(&b, "  - %s\n", strconv.Quote(v))
+		}
+	}
+
+	if len(res.Parts) > 0 {
+		list("parts", res.Parts)
+		list("normalized_parts", res.NormalizedParts)
+	} else {
+		field("input", res.Input)
+		field("normalized", res.Normalized)
+	}
+	field("algorithm", res.Algorithm)
+	fmt.Fprintf(&b, "version: %d\n", res.Version)
+	field("uuid", res.UUID)
+	field("short_id", res.ShortID)
+	field("fingerprint", res.Fingerprint)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
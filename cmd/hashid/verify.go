@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runVerify checks whether an ID was generated from the input,
+// it exits with exitOK on a match and exitFailure otherwise
+func runVerify(args []string) int {
+	conf := config{}
+	quiet := false
+
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	conf.registerFlags(fs)
+	fs.BoolVar(&quiet, "quiet", false, "Only report the result through the exit code")
+	fs.Usage = verifyUsage
+	fs.Parse(args)
+
+	if len(conf.parts) > 0 {
+		fmt.Fprintln(os.Stderr, "Error: -part is not supported by verify")
+		return exitUsage
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprint(os.Stderr, "Error: An input string and an ID are required\n\n")
+		verifyUsage()
+		return exitUsage
+	}
+
+	gen, err := conf.generator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	ok, err := gen.Verify(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if !ok {
+		if !quiet {
+			fmt.Println("MISMATCH")
+		}
+		return exitFailure
+	}
+
+	if !quiet {
+		fmt.Println("OK")
+	}
+	return exitOK
+}
@@ -0,0 +1,180 @@
+package hashid
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
+)
+
+// HasherFactory builds a new hash.Hash. The key is the one
+// set with WithHMACKey, it is nil when no key was given.
+type HasherFactory func(key []byte) (hash.Hash, error)
+
+// algorithm describes how to build a hasher
+type algorithm struct {
+	factory HasherFactory
+	version int
+	keyed   bool
+}
+
+var (
+	algorithms = map[HashAlgorithm]algorithm{
+		MD5:      unkeyed(md5.New, 3),
+		SHA1:     unkeyed(sha1.New, 5),
+		SHA256:   unkeyed(sha256.New, 8),
+		SHA512:   unkeyed(sha512.New, 8),
+		SHA3_256: unkeyed(sha3.New256, 8),
+		BLAKE2B:  unkeyed(newBlake2b, 8),
+		BLAKE3:   unkeyed(newBlake3, 8),
+
+		HMAC_SHA256:   hmacOf(HMAC_SHA256, sha256.New),
+		HMAC_SHA512:   hmacOf(HMAC_SHA512, sha512.New),
+		HMAC_SHA3_256: hmacOf(HMAC_SHA3_256, sha3.New256),
+		HMAC_BLAKE2B:  hmacOf(HMAC_BLAKE2B, newBlake2b),
+		HMAC_BLAKE3:   hmacOf(HMAC_BLAKE3, newBlake3),
+	}
+	algorithmsMu sync.RWMutex
+)
+
+// RegisterAlgorithm makes a custom unkeyed hashing algorithm
+// available to WithHashAlgorithm and every New* function. The
+// factory always receives a nil key, like with the built-in
+// unkeyed algorithms NewGenerator rejects a key set with
+// WithHMACKey with ErrInvalidKey. defaultUUIDVersion is the version used when the algorithm is
+// selected, it must be one of 3, 5 or 8.
+//
+// Built-in algorithms can not be replaced and each name can only
+// be registered once. It is safe to call RegisterAlgorithm
+// concurrently, although it is typically called from init.
+//
+// Example:
+//
+//	err := hashid.RegisterAlgorithm("sha512-256",
+//		func([]byte) (hash.Hash, error) {
+//			return sha512.New512_256(), nil
+//		}, 8)
+func RegisterAlgorithm(name HashAlgorithm, factory HasherFactory, defaultUUIDVersion int) error {
+	return registerAlgorithm(name, factory, defaultUUIDVersion, false)
+}
+
+// RegisterKeyedAlgorithm is like RegisterAlgorithm for algorithms
+// that use the key set with WithHMACKey. The factory receives the
+// key, and should return an error when it is nil.
+//
+// Example:
+//
+//	err := hashid.RegisterKeyedAlgorithm("keyed-sha256",
+//		func(key []byte) (hash.Hash, error) {
+//			if key == nil {
+//				return nil, errors.New("key required")
+//			}
+//			return hmac.New(sha256.New, key), nil
+//		}, 8)
+func RegisterKeyedAlgorithm(name HashAlgorithm, factory HasherFactory, defaultUUIDVersion int) error {
+	return registerAlgorithm(name, factory, defaultUUIDVersion, true)
+}
+
+func registerAlgorithm(name HashAlgorithm, factory HasherFactory, defaultUUIDVersion int, keyed bool) error {
+	if name == "" {
+		return fmt.Errorf("algorithm name is required")
+	}
+
+	if factory == nil {
+		return fmt.Errorf("algorithm %s: factory is required", name)
+	}
+
+	switch defaultUUIDVersion {
+	case 3, 5, 8:
+	default:
+		return fmt.Errorf("algorithm %s: %w: %d, should be one of 3, 5, 8", name, ErrUnsupportedVersion, defaultUUIDVersion)
+	}
+
+	algorithmsMu.Lock()
+	defer algorithmsMu.Unlock()
+
+	if _, ok := algorithms[name]; ok {
+		return fmt.Errorf("algorithm %s is already registered", name)
+	}
+
+	algorithms[name] = algorithm{
+		factory: factory,
+		version: defaultUUIDVersion,
+		keyed:   keyed,
+	}
+	return nil
+}
+
+// Algorithms returns the names of all available hashing
+// algorithms, including registered ones, sorted by name.
+func Algorithms() []HashAlgorithm {
+	algorithmsMu.RLock()
+	defer algorithmsMu.RUnlock()
+
+	names := make([]HashAlgorithm, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// DefaultVersion returns the UUID version used by default with
+// the algorithm, or 0 if the algorithm is not known.
+func (a HashAlgorithm) DefaultVersion() int {
+	spec, _ := lookupAlgorithm(a)
+	return spec.version
+}
+
+// Keyed reports whether the algorithm uses the key set
+// with WithHMACKey.
+func (a HashAlgorithm) Keyed() bool {
+	spec, _ := lookupAlgorithm(a)
+	return spec.keyed
+}
+
+func lookupAlgorithm(algo HashAlgorithm) (algorithm, bool) {
+	algorithmsMu.RLock()
+	defer algorithmsMu.RUnlock()
+	spec, ok := algorithms[algo]
+	return spec, ok
+}
+
+func unkeyed(factory func() hash.Hash, version int) algorithm {
+	return algorithm{
+		factory: func([]byte) (hash.Hash, error) { return factory(), nil },
+		version: version,
+	}
+}
+
+func hmacOf(name HashAlgorithm, factory func() hash.Hash) algorithm {
+	return algorithm{
+		factory: func(key []byte) (hash.Hash, error) {
+			if key == nil {
+				return nil, fmt.Errorf("%w: required when using %s", ErrMissingKey, name)
+			}
+			return hmac.New(factory, key), nil
+		},
+		version: 8,
+		keyed:   true,
+	}
+}
+
+func newBlake2b() hash.Hash {
+	// New256 only fails for keys longer than 64 bytes
+	h, _ := blake2b.New256(nil)
+	return h
+}
+
+func newBlake3() hash.Hash {
+	return blake3.New(32, nil)
+}
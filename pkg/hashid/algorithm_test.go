@@ -0,0 +1,241 @@
+package hashid
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestAlgorithms(t *testing.T) {
+	key := []byte("secret")
+
+	testCases := []struct {
+		algo    HashAlgorithm
+		version uuid.Version
+		hasher  hash.Hash
+	}{
+		{SHA512, 8, sha512.New()},
+		{SHA3_256, 8, sha3.New256()},
+		{BLAKE2B, 8, newBlake2b()},
+		{BLAKE3, 8, newBlake3()},
+		{HMAC_SHA512, 8, hmac.New(sha512.New, key)},
+		{HMAC_SHA3_256, 8, hmac.New(sha3.New256, key)},
+		{HMAC_BLAKE2B, 8, hmac.New(newBlake2b, key)},
+		{HMAC_BLAKE3, 8, hmac.New(newBlake3, key)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.algo), func(t *testing.T) {
+			opts := []Option{WithHashAlgorithm(tc.algo), WithNormalization(false)}
+			if tc.algo.Keyed() {
+				opts = append(opts, WithHMACKey(key))
+			}
+
+			uid, err := NewUUID("user@example.com", opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.version, uid.Version())
+			assert.Equal(t, uuid.RFC4122, uid.Variant())
+
+			tc.hasher.Write([]byte("user@example.com"))
+			assert.Equal(t, formatUUID(tc.hasher.Sum(nil), int(tc.version)), uid)
+		})
+	}
+}
+
+func TestHMACAlgorithmsRequireKey(t *testing.T) {
+	for _, algo := range []HashAlgorithm{HMAC_SHA256, HMAC_SHA512, HMAC_SHA3_256, HMAC_BLAKE2B, HMAC_BLAKE3} {
+		_, err := New("test", WithHashAlgorithm(algo))
+		assert.Error(t, err, string(algo))
+	}
+}
+
+func TestWithHMACKeyKeepsHMACAlgorithm(t *testing.T) {
+	key := []byte("secret")
+
+	// the option order does not matter
+	for _, opts := range [][]Option{
+		{WithHashAlgorithm(HMAC_SHA512), WithHMACKey(key)},
+		{WithHMACKey(key), WithHashAlgorithm(HMAC_SHA512)},
+	} {
+		gen, err := NewGenerator(opts...)
+		require.NoError(t, err)
+		assert.Equal(t, HMAC_SHA512, gen.Algorithm())
+	}
+
+	// without an algorithm the key selects HMAC_SHA256
+	gen, err := NewGenerator(WithHMACKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, HMAC_SHA256, gen.Algorithm())
+
+	// unkeyed algorithms are never replaced
+	for _, opts := range [][]Option{
+		{WithHashAlgorithm(SHA512), WithHMACKey(key)},
+		{WithHMACKey(key), WithHashAlgorithm(SHA512)},
+		{WithHMACKey(key), WithHashAlgorithm(MD5)},
+	} {
+		_, err := NewGenerator(opts...)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+
+		var cerr *ConfigError
+		require.ErrorAs(t, err, &cerr)
+		assert.Equal(t, "key", cerr.Field)
+	}
+}
+
+func TestUnsupportedAlgorithm(t *testing.T) {
+	_, err := New("test", WithHashAlgorithm("crc32"))
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+
+	_, err = NewGenerator(WithHashAlgorithm(""))
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestRegisterAlgorithm(t *testing.T) {
+	const custom HashAlgorithm = "test-keyed-sha512"
+
+	err := RegisterKeyedAlgorithm(custom, func(key []byte) (hash.Hash, error) {
+		if key == nil {
+			return nil, errors.New("key required")
+		}
+		return hmac.New(sha512.New, append([]byte("pepper"), key...)), nil
+	}, 5)
+	require.NoError(t, err)
+
+	assert.Contains(t, Algorithms(), custom)
+	assert.Equal(t, 5, custom.DefaultVersion())
+	assert.True(t, custom.Keyed())
+
+	_, err = New("test", WithHashAlgorithm(custom))
+	assert.Error(t, err, "factory requires a key")
+
+	key := []byte("secret")
+	opts := []Option{WithHashAlgorithm(custom), WithHMACKey(key), WithNormalization(false)}
+
+	uid, err := NewUUID("test", opts...)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(5), uid.Version())
+
+	// the key reaches the factory in any order
+	reversed, err := NewUUID("test", WithHMACKey(key), WithHashAlgorithm(custom), WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, uid, reversed)
+
+	mac := hmac.New(sha512.New, []byte("peppersecret"))
+	mac.Write([]byte("test"))
+	assert.Equal(t, formatUUID(mac.Sum(nil), 5), uid)
+
+	sid, err := NewShortID("test", opts...)
+	require.NoError(t, err)
+	parsed, err := ParseShortID(sid)
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	parts, err := NewUUIDFromParts([]string{"a", "b"}, opts...)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(5), parts.Version())
+}
+
+func TestRegisterUnkeyedAlgorithm(t *testing.T) {
+	const custom HashAlgorithm = "test-unkeyed-sha512"
+
+	err := RegisterAlgorithm(custom, func(key []byte) (hash.Hash, error) {
+		return sha512.New(), nil
+	}, 8)
+	require.NoError(t, err)
+	assert.False(t, custom.Keyed())
+
+	uid, err := NewUUID("test", WithHashAlgorithm(custom), WithNormalization(false))
+	require.NoError(t, err)
+	h := sha512.New()
+	h.Write([]byte("test"))
+	assert.Equal(t, formatUUID(h.Sum(nil), 8), uid)
+
+	// like the built-in unkeyed algorithms a key is rejected, in any order
+	_, err = NewGenerator(WithHashAlgorithm(custom), WithHMACKey([]byte("secret")))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewUUID("test", WithHMACKey([]byte("secret")), WithHashAlgorithm(custom))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestAlgorithmFactoryFailsLater(t *testing.T) {
+	const custom HashAlgorithm = "test-fails-later"
+
+	var calls atomic.Int32
+	err := RegisterAlgorithm(custom, func([]byte) (hash.Hash, error) {
+		switch calls.Add(1) {
+		case 1:
+			return sha512.New(), nil
+		case 2:
+			return nil, errors.New("hasher unavailable")
+		default:
+			return nil, nil
+		}
+	}, 8)
+	require.NoError(t, err)
+
+	gen, err := NewGenerator(WithHashAlgorithm(custom))
+	require.NoError(t, err)
+
+	// drain the hasher created by NewGenerator so the pool has to refill
+	for gen.hashers.Get() != nil {
+	}
+
+	_, err = gen.New("test")
+	assert.ErrorContains(t, err, "hasher unavailable")
+
+	_, err = gen.New("test")
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRegisterAlgorithmErrors(t *testing.T) {
+	factory := func(key []byte) (hash.Hash, error) { return sha512.New(), nil }
+
+	assert.Error(t, RegisterAlgorithm("", factory, 8))
+	assert.Error(t, RegisterAlgorithm("test-nil-factory", nil, 8))
+	assert.Error(t, RegisterAlgorithm("test-bad-version", factory, 4))
+	assert.Error(t, RegisterAlgorithm(SHA256, factory, 8), "built-in")
+
+	require.NoError(t, RegisterAlgorithm("test-twice", factory, 8))
+	assert.Error(t, RegisterAlgorithm("test-twice", factory, 8))
+	assert.Error(t, RegisterKeyedAlgorithm("test-twice", factory, 8))
+	assert.Error(t, RegisterKeyedAlgorithm("test-keyed-nil-factory", nil, 8))
+
+	require.NoError(t, RegisterAlgorithm("test-short-digest", func(key []byte) (hash.Hash, error) {
+		return fnv.New64a(), nil
+	}, 8))
+	_, err := New("test", WithHashAlgorithm("test-short-digest"))
+	assert.Error(t, err)
+}
+
+func TestRegisterAlgorithmConcurrent(t *testing.T) {
+	factory := func(key []byte) (hash.Hash, error) { return sha512.New(), nil }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := HashAlgorithm(fmt.Sprintf("test-concurrent-%d", i))
+			assert.NoError(t, RegisterAlgorithm(name, factory, 8))
+			_, err := New("test", WithHashAlgorithm(name))
+			assert.NoError(t, err)
+			_, err = New("test", WithHashAlgorithm(SHA1))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,209 @@
+package hashid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// CaseFolding selects how letter case is removed during normalization.
+type CaseFolding int
+
+const (
+	// CaseLower lower cases the input, using the rules of the
+	// locale when one is set. It is the default.
+	CaseLower CaseFolding = iota
+	// CaseFoldSimple applies Unicode simple case folding, which maps
+	// each character to a single character: "ς" and "σ" match, but
+	// "ß" is kept.
+	CaseFoldSimple
+	// CaseFoldFull applies Unicode full case folding, which can map
+	// a character to several: "ß" folds to "ss" and matches "SS".
+	CaseFoldFull
+)
+
+var caseFoldingNames = map[CaseFolding]string{
+	CaseLower:      "lower",
+	CaseFoldSimple: "simple",
+	CaseFoldFull:   "full",
+}
+
+// String returns the name of the mode, as accepted by ParseCaseFolding.
+func (c CaseFolding) String() string {
+	if name, ok := caseFoldingNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("CaseFolding(%d)", int(c))
+}
+
+// ParseCaseFolding returns the mode named lower, simple or full.
+// Unknown names return a *ConfigError wrapping ErrUnknownCaseFolding.
+func ParseCaseFolding(name string) (CaseFolding, error) {
+	for mode, n := range caseFoldingNames {
+		if strings.EqualFold(name, n) {
+			return mode, nil
+		}
+	}
+	return 0, caseFoldingError(name)
+}
+
+func caseFoldingError(name string) error {
+	return &ConfigError{Field: "casefolding", Err: fmt.Errorf("%w: %q", ErrUnknownCaseFolding, name)}
+}
+
+// WithCaseFolding selects how the default normalizer removes letter
+// case, see CaseFoldWith. The default charmap already transliterates
+// characters such as "ß", so only characters outside of it change.
+// It has no effect on WithPipeline, use a CaseFoldWith step instead,
+// or on custom normalizers.
+//
+// Example:
+//
+//	id, _ := hashid.New("FINANCE", hashid.WithCaseFolding(hashid.CaseFoldFull))
+//	// same ID as hashid.New("ﬁnance", hashid.WithCaseFolding(hashid.CaseFoldFull))
+func WithCaseFolding(mode CaseFolding) Option {
+	return func(o *options) {
+		o.caseFolding = mode
+	}
+}
+
+// WithLocale sets the language whose case rules the default normalizer
+// applies, e.g. language.Turkish lower cases "I" to "ı" and "İ" to "i".
+// The default charmap transliterates "ı" and "İ" to "i" anyway, so the
+// Turkish rules only make a difference with WithCustomCharMap. Like
+// WithCaseFolding it has no effect on pipelines and custom normalizers.
+func WithLocale(tag language.Tag) Option {
+	return func(o *options) {
+		o.locale = tag
+	}
+}
+
+// caseStep returns the step for the configured case folding and
+// locale, false when they are the defaults which the final casefold
+// step of the default pipeline already covers.
+func (o options) caseStep() (Step, bool) {
+	if o.caseFolding == CaseLower && o.locale == language.Und {
+		return Step{}, false
+	}
+	return CaseFoldWith(o.caseFolding, o.locale), true
+}
+
+// withCaseStep inserts step after the Unicode form step of p, so the
+// locale rules see the characters before the charmap transliterates
+// them, e.g. Turkish "İ" to "I". Folding can decompose characters so
+// the form is applied again, and the final casefold step is kept to
+// lower case the output of the charmap.
+func withCaseStep(p Pipeline, step Step) Pipeline {
+	if len(p) == 0 {
+		return Pipeline{step}
+	}
+	if _, ok := unicodeForms[p[0].name]; !ok {
+		return append(Pipeline{step}, p...)
+	}
+
+	out := make(Pipeline, 0, len(p)+2)
+	out = append(out, p[0], step, p[0])
+	return append(out, p[1:]...)
+}
+
+// CaseFoldWith removes letter case using mode. When tag is not
+// language.Und the input is first lower cased with the rules of the
+// language, which handles Turkish and Azeri dotted and dotless i,
+// before being folded. CaseFoldWith(CaseLower, language.Und) is CaseFold.
+//
+// Its spec is `casefold:"mode"` or `casefold:"mode":"tag"`, e.g.
+// `casefold:"full":"de"`.
+func CaseFoldWith(mode CaseFolding, tag language.Tag) Step {
+	if mode == CaseLower && tag == language.Und {
+		return CaseFold()
+	}
+
+	step := Step{
+		name: "casefold",
+		args: []string{mode.String()},
+	}
+	if tag != language.Und {
+		step.args = append(step.args, tag.String())
+	}
+
+	if _, ok := caseFoldingNames[mode]; !ok {
+		err := caseFoldingError(mode.String())
+		step.fn = func(string) (string, error) {
+			return "", err
+		}
+		return step
+	}
+
+	step.fn = func(s string) (string, error) {
+		// a Caser holds state and can not be shared between goroutines
+		if tag != language.Und {
+			s = cases.Lower(tag).String(s)
+		}
+
+		switch mode {
+		case CaseFoldSimple:
+			s = strings.Map(simpleFold, s)
+		case CaseFoldFull:
+			s = cases.Fold().String(s)
+		}
+		return s, nil
+	}
+	return step
+}
+
+// simpleFoldTargets holds the simple foldings that case mappings
+// do not give: the characters of these orbits have no upper or
+// lower case, CaseFolding.txt folds them to an older equivalent.
+var simpleFoldTargets = map[rune]rune{
+	'\u1FD3': '\u0390', // ΐ
+	'\u1FE3': '\u03B0', // ΰ
+	'\uFB05': '\uFB06', // ﬅ
+}
+
+// simpleFold maps r to its simple case folding, the C and S entries
+// of CaseFolding.txt. The folding is the member of the orbit of r,
+// see unicode.SimpleFold, that r lower cases to through its upper
+// case. Cherokee folds to upper case since its lower case letters
+// were added later, and dotted capital I and dotless i have no
+// simple folding, their lower case is outside of their orbit.
+func simpleFold(r rune) rune {
+	if target, ok := simpleFoldTargets[r]; ok {
+		return target
+	}
+
+	target := unicode.ToLower(unicode.ToUpper(r))
+	if unicode.Is(unicode.Cherokee, r) {
+		target = unicode.ToUpper(r)
+	}
+
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f == target {
+			return target
+		}
+	}
+	return r
+}
+
+// newCaseFoldStep parses the arguments of a casefold spec
+func newCaseFoldStep(args []string) (Step, error) {
+	if len(args) == 0 {
+		return CaseFold(), nil
+	}
+
+	mode, err := ParseCaseFolding(args[0])
+	if err != nil {
+		return Step{}, errors.Unwrap(err)
+	}
+
+	tag := language.Und
+	if len(args) == 2 {
+		if tag, err = language.Parse(args[1]); err != nil {
+			return Step{}, fmt.Errorf("%w: invalid locale %q: %w", ErrInvalidPipeline, args[1], err)
+		}
+	}
+	return CaseFoldWith(mode, tag), nil
+}
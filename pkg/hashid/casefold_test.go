@@ -0,0 +1,218 @@
+package hashid
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestCaseFoldWith(t *testing.T) {
+	testCases := []struct {
+		mode     CaseFolding
+		tag      language.Tag
+		input    string
+		expected string
+	}{
+		{CaseLower, language.Und, "STRASSE", "strasse"},
+		{CaseLower, language.Und, "Straße", "straße"},
+		{CaseLower, language.Und, "ΟΔΟΣ", "οδοσ"},
+		{CaseLower, language.Greek, "ΟΔΟΣ", "οδος"},
+		{CaseLower, language.Turkish, "ISTANBUL", "ıstanbul"},
+		{CaseLower, language.Turkish, "İstanbul", "istanbul"},
+		{CaseLower, language.German, "ISTANBUL", "istanbul"},
+		{CaseFoldSimple, language.Und, "οδος", "οδοσ"},
+		{CaseFoldSimple, language.Und, "Straße", "straße"},
+		{CaseFoldSimple, language.Und, "ẞ", "ß"},
+		{CaseFoldSimple, language.Und, "K", "k"},
+		{CaseFoldSimple, language.Und, "ıİ", "ıİ"},
+		{CaseFoldSimple, language.Und, "ꭰᏸᎠ", "ᎠᏰᎠ"},
+		{CaseFoldSimple, language.Und, "\u1FD3\uFB05", "\u0390\uFB06"},
+		{CaseFoldSimple, language.Turkish, "Iİ", "ıi"},
+		{CaseFoldFull, language.Und, "Straße", "strasse"},
+		{CaseFoldFull, language.Und, "STRASSE", "strasse"},
+		{CaseFoldFull, language.Und, "οδος", "οδοσ"},
+		{CaseFoldFull, language.Und, "ﬁx", "fix"},
+		{CaseFoldFull, language.Und, "İ", "i̇"},
+		{CaseFoldFull, language.Turkish, "IİSS", "ıiss"},
+		{CaseFoldFull, language.German, "Maß", "mass"},
+	}
+
+	for _, tc := range testCases {
+		step := CaseFoldWith(tc.mode, tc.tag)
+		t.Run(step.String()+" "+tc.input, func(t *testing.T) {
+			out, err := Pipeline{step}.Normalize(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestSimpleFoldOrbits(t *testing.T) {
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		folded := simpleFold(r)
+		if simpleFold(folded) != folded {
+			t.Fatalf("%U folds to %U which folds again", r, folded)
+		}
+
+		// every character of an orbit folds the same
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if simpleFold(f) != folded {
+				t.Fatalf("%U folds to %U but %U folds to %U", r, folded, f, simpleFold(f))
+			}
+		}
+	}
+}
+
+func TestParseCaseFolding(t *testing.T) {
+	for name, expected := range map[string]CaseFolding{
+		"lower":  CaseLower,
+		"simple": CaseFoldSimple,
+		"FULL":   CaseFoldFull,
+	} {
+		mode, err := ParseCaseFolding(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, mode, name)
+	}
+
+	_, err := ParseCaseFolding("upper")
+	assert.ErrorIs(t, err, ErrUnknownCaseFolding)
+
+	var cerr *ConfigError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "casefolding", cerr.Field)
+
+	assert.Equal(t, "full", CaseFoldFull.String())
+	assert.Equal(t, "CaseFolding(7)", CaseFolding(7).String())
+}
+
+func TestCaseFoldPipeline(t *testing.T) {
+	for spec, expected := range map[string]string{
+		`casefold`:                  `casefold`,
+		`casefold:"lower"`:          `casefold`,
+		`casefold:"full"`:           `casefold:"full"`,
+		`casefold:"Simple":"tr-TR"`: `casefold:"simple":"tr-TR"`,
+		`casefold:"lower":"az"`:     `casefold:"lower":"az"`,
+	} {
+		p, err := ParsePipeline(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, expected, p.String(), spec)
+	}
+
+	p, err := ParsePipeline(`nfc,trim,casefold:"full":"tr"`)
+	require.NoError(t, err)
+	out, err := p.Normalize(" İSTANBUL Straße ")
+	require.NoError(t, err)
+	assert.Equal(t, "istanbul strasse", out)
+
+	_, err = ParsePipeline(`casefold:"upper"`)
+	assert.ErrorIs(t, err, ErrUnknownCaseFolding)
+
+	_, err = ParsePipeline(`casefold:"full":"not a tag"`)
+	assert.ErrorIs(t, err, ErrInvalidPipeline)
+
+	_, err = ParsePipeline(`casefold:"full":"tr":"x"`)
+	assert.ErrorIs(t, err, ErrInvalidPipeline)
+
+	_, err = Pipeline{CaseFoldWith(CaseFolding(7), language.Und)}.Normalize("x")
+	assert.ErrorIs(t, err, ErrUnknownCaseFolding)
+}
+
+func TestWithCaseFolding(t *testing.T) {
+	// the default normalizer output does not change
+	a, err := New("Straße ΟΔΟΣ")
+	require.NoError(t, err)
+	b, err := New("Straße ΟΔΟΣ", WithCaseFolding(CaseLower), WithLocale(language.Und))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// characters outside the charmap are folded
+	a, err = New("ﬁx")
+	require.NoError(t, err)
+	b, err = New("FIX")
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	a, err = New("ﬁx", WithCaseFolding(CaseFoldFull))
+	require.NoError(t, err)
+	b, err = New("FIX", WithCaseFolding(CaseFoldFull))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// decomposed characters are still transliterated
+	g, err := NewGenerator(WithCaseFolding(CaseFoldFull))
+	require.NoError(t, err)
+	out, err := g.Normalize("ΐ")
+	require.NoError(t, err)
+	assert.Equal(t, "i", out)
+
+	_, err = NewGenerator(WithCaseFolding(CaseFolding(7)))
+	assert.ErrorIs(t, err, ErrUnknownCaseFolding)
+}
+
+func TestWithLocale(t *testing.T) {
+	// without the default charmap "ı" is not transliterated to "i"
+	opts := []Option{WithCustomCharMap(map[string]string{}), WithLocale(language.Turkish)}
+
+	a, err := New("ISPARTA", opts...)
+	require.NoError(t, err)
+	b, err := New("ısparta", opts...)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := New("İzmir", opts...)
+	require.NoError(t, err)
+	d, err := New("izmir", opts...)
+	require.NoError(t, err)
+	assert.Equal(t, c, d)
+
+	a, err = New("ISPARTA", WithCustomCharMap(map[string]string{}))
+	require.NoError(t, err)
+	b, err = New("ısparta", WithCustomCharMap(map[string]string{}))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestCaseFoldingFingerprint(t *testing.T) {
+	fingerprint := func(opts ...Option) string {
+		g, err := NewGenerator(opts...)
+		require.NoError(t, err)
+		return g.Fingerprint()
+	}
+
+	base := fingerprint()
+	assert.Equal(t, base, fingerprint(WithCaseFolding(CaseLower)))
+	assert.NotEqual(t, base, fingerprint(WithCaseFolding(CaseFoldFull)))
+	assert.NotEqual(t, base, fingerprint(WithLocale(language.Turkish)))
+	assert.NotEqual(t, fingerprint(WithLocale(language.Turkish)), fingerprint(WithLocale(language.German)))
+
+	// options that change the fingerprint change the output of the
+	// default normalizer for characters outside the charmap
+	normalize := func(input string, opts ...Option) string {
+		g, err := NewGenerator(opts...)
+		require.NoError(t, err)
+		out, err := g.Normalize(input)
+		require.NoError(t, err)
+		return out
+	}
+
+	assert.Equal(t, "ﬁnance", normalize("ﬁnance"))
+	assert.Equal(t, "finance", normalize("ﬁnance", WithCaseFolding(CaseFoldFull)))
+	assert.Equal(t, "ſ", normalize("ſ"))
+	assert.Equal(t, "s", normalize("ſ", WithCaseFolding(CaseFoldSimple)))
+
+	// the locale lower cases before the charmap, which only maps "ƒ"
+	assert.Equal(t, "ƒ", normalize("Ƒ"))
+	assert.Equal(t, "f", normalize("Ƒ", WithLocale(language.Turkish)))
+
+	// the charmap already maps the Turkish dotted and dotless i
+	assert.Equal(t, "isparta", normalize("ISPARTA"))
+	assert.Equal(t, "isparta", normalize("ISPARTA", WithLocale(language.Turkish)))
+	assert.Equal(t, "ısparta", normalize("ISPARTA", WithCustomCharMap(map[string]string{}), WithLocale(language.Turkish)))
+
+	// case options do not apply to pipelines
+	p := DefaultPipeline()
+	assert.Equal(t, fingerprint(WithPipeline(p)), fingerprint(WithPipeline(p), WithCaseFolding(CaseFoldFull)))
+}
@@ -3,8 +3,10 @@ package hashid
 import (
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"unicode/utf8"
 )
 
 //go:embed charmap.json
@@ -31,6 +33,31 @@ func GetCharMap() (map[string]string, error) {
 	return copy, nil
 }
 
+// ValidateCharMap checks that a character map can be used
+// for normalization. Keys must be a single valid character,
+// since the input is mapped one character at a time, and
+// values must be valid UTF-8.
+func ValidateCharMap(mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return charMapError(fmt.Errorf("%w: charmap is empty", ErrInvalidCharMap))
+	}
+
+	for k, v := range mapping {
+		if !utf8.ValidString(k) || utf8.RuneCountInString(k) != 1 {
+			return charMapError(fmt.Errorf("%w: key %q must be a single character", ErrInvalidCharMap, k))
+		}
+
+		if unicodeNorm(k) != k {
+			return charMapError(fmt.Errorf("%w: key %q is not in NFC form", ErrInvalidCharMap, k))
+		}
+
+		if !utf8.ValidString(v) {
+			return charMapError(fmt.Errorf("%w: value for key %q is not valid UTF-8", ErrInvalidCharMap, k))
+		}
+	}
+	return nil
+}
+
 func SetCharMap(mapping map[string]string) {
 	charMap = mapping
 	initError = nil
@@ -58,8 +85,17 @@ func initDefaultCharMap() {
 func loadCharMap(data []byte) (map[string]string, error) {
 	var mapping map[string]string
 	err := json.Unmarshal(data, &mapping)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal charmap: %w", err)
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return nil, charMapError(fmt.Errorf("%w: %w at offset %d", ErrInvalidCharMap, err, syntaxErr.Offset))
+	case err != nil:
+		return nil, charMapError(fmt.Errorf("%w: %w", ErrInvalidCharMap, err))
 	}
 	return mapping, nil
 }
+
+func charMapError(err error) error {
+	return &ConfigError{Field: "charmap", Err: err}
+}
@@ -114,3 +114,23 @@ func TestWithCustomCharMap(t *testing.T) {
 
 	ResetCharMap()
 }
+
+func TestValidateCharMap(t *testing.T) {
+	defaultMap, err := GetCharMap()
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateCharMap(defaultMap))
+
+	testCases := map[string]map[string]string{
+		"empty":         {},
+		"multi char":    {"ab": "c"},
+		"empty key":     {"": "c"},
+		"invalid value": {"a": "\xff"},
+		"not NFC":       {"\u212b": "A"},
+	}
+
+	for name, mapping := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateCharMap(mapping))
+		})
+	}
+}
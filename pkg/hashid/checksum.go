@@ -0,0 +1,144 @@
+package hashid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// HumanReadable is meant for IDs that are read aloud or typed
+// by hand. It uses the Crockford base32 alphabet, appends a
+// Luhn mod 32 check character, and groups characters by four,
+// e.g. 583T-E76H-GC7T-ET4H-RY5F-6J1S-YSR. Decoding ignores
+// case, hyphens and spaces, and reports transcription errors
+// with ErrChecksumMismatch instead of decoding a wrong UUID.
+var HumanReadable = mustChecksumEncoding(Base32Crockford.(*alphabetEncoding), 4)
+
+// groupSeparator separates character groups
+const groupSeparator = '-'
+
+// NewChecksumEncoding creates an Encoding from a custom alphabet
+// that appends a Luhn mod N check character, N being the length
+// of the alphabet, and splits IDs in groups of groupSize characters.
+// A groupSize of 0 disables grouping. The alphabet must not
+// contain hyphens or spaces.
+func NewChecksumEncoding(alphabet string, groupSize int) (Encoding, error) {
+	digits, err := newAlphabetEncoding(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	return newChecksumEncoding(digits, groupSize)
+}
+
+// checksumEncoding adds a check character and grouping
+// to an alphabet encoding
+type checksumEncoding struct {
+	digits    *alphabetEncoding
+	groupSize int
+}
+
+func newChecksumEncoding(digits *alphabetEncoding, groupSize int) (*checksumEncoding, error) {
+	if groupSize < 0 {
+		return nil, encodingError(fmt.Errorf("%w: group size must not be negative", ErrInvalidAlphabet))
+	}
+
+	if strings.ContainsAny(string(digits.alphabet), " -") {
+		return nil, encodingError(fmt.Errorf("%w: hyphens and spaces are used as separators", ErrInvalidAlphabet))
+	}
+
+	return &checksumEncoding{digits: digits, groupSize: groupSize}, nil
+}
+
+func mustChecksumEncoding(digits *alphabetEncoding, groupSize int) Encoding {
+	e, err := newChecksumEncoding(digits, groupSize)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *checksumEncoding) Encode(uid uuid.UUID) string {
+	data := []rune(e.digits.Encode(uid))
+
+	codes := make([]uint64, len(data))
+	for i, ch := range data {
+		codes[i] = e.digits.index[ch]
+	}
+	data = append(data, e.digits.alphabet[luhnCheck(codes, e.digits.base)])
+
+	if e.groupSize == 0 {
+		return string(data)
+	}
+
+	var out strings.Builder
+	for i, ch := range data {
+		if i > 0 && i%e.groupSize == 0 {
+			out.WriteRune(groupSeparator)
+		}
+		out.WriteRune(ch)
+	}
+	return out.String()
+}
+
+func (e *checksumEncoding) Decode(sid string) (uuid.UUID, error) {
+	codes := make([]uint64, 0, e.digits.length+1)
+	for i, ch := range sid {
+		if ch == groupSeparator || ch == ' ' {
+			continue
+		}
+
+		code, ok := e.digits.index[ch]
+		if !ok {
+			return uuid.Nil, &ParseError{Input: sid, Position: i, Err: ErrInvalidShortID}
+		}
+		codes = append(codes, code)
+	}
+
+	if len(codes) != e.digits.length+1 {
+		return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+	}
+
+	if !luhnValid(codes, e.digits.base) {
+		return uuid.Nil, &ParseError{
+			Input:    sid,
+			Position: -1,
+			Err:      fmt.Errorf("%w: %w", ErrInvalidShortID, ErrChecksumMismatch),
+		}
+	}
+
+	// decode the canonical form, without aliases or lower case
+	data := make([]rune, e.digits.length)
+	for i, code := range codes[:e.digits.length] {
+		data[i] = e.digits.alphabet[code]
+	}
+
+	uid, err := e.digits.Decode(string(data))
+	if err != nil {
+		return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+	}
+	return uid, nil
+}
+
+// luhnCheck returns the Luhn mod N check code of codes
+func luhnCheck(codes []uint64, n uint64) uint64 {
+	return (n - luhnSum(codes, 2, n)%n) % n
+}
+
+// luhnValid reports whether the last code is the
+// Luhn mod N check code of the others
+func luhnValid(codes []uint64, n uint64) bool {
+	return luhnSum(codes, 1, n)%n == 0
+}
+
+// luhnSum doubles every other code, starting from the
+// right with factor, and adds the digits in base n
+func luhnSum(codes []uint64, factor, n uint64) uint64 {
+	sum := uint64(0)
+	for i := len(codes) - 1; i >= 0; i-- {
+		addend := factor * codes[i]
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return sum
+}
@@ -0,0 +1,181 @@
+package hashid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanReadable(t *testing.T) {
+	for _, uid := range testUUIDs(t) {
+		sid := HumanReadable.Encode(uid)
+		assert.Len(t, sid, 33)
+
+		groups := strings.Split(sid, "-")
+		assert.Len(t, groups, 7)
+		for _, group := range groups[:6] {
+			assert.Len(t, group, 4)
+		}
+
+		// the data characters are the Crockford encoding
+		assert.Equal(t, Base32Crockford.Encode(uid), strings.Join(groups, "")[:26])
+
+		decoded, err := HumanReadable.Decode(sid)
+		require.NoError(t, err)
+		assert.Equal(t, uid, decoded)
+	}
+}
+
+func TestHumanReadableLenientDecoding(t *testing.T) {
+	uid, err := NewUUID("user@example.com")
+	require.NoError(t, err)
+
+	sid := HumanReadable.Encode(uid)
+	compact := strings.ReplaceAll(sid, "-", "")
+
+	for _, input := range []string{
+		strings.ToLower(sid),
+		compact,
+		strings.ReplaceAll(sid, "-", " "),
+		compact[:10] + "-" + compact[10:],
+	} {
+		decoded, err := HumanReadable.Decode(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, uid, decoded)
+	}
+
+	// aliases are checked as the character they stand for
+	zero := HumanReadable.Encode(uuid.Nil)
+	decoded, err := HumanReadable.Decode(strings.ReplaceAll(zero, "0", "O"))
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, decoded)
+}
+
+func TestHumanReadableTranscriptionErrors(t *testing.T) {
+	alphabet := "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	for _, uid := range testUUIDs(t) {
+		compact := []byte(strings.ReplaceAll(HumanReadable.Encode(uid), "-", ""))
+
+		// every single character substitution is detected
+		for i := range compact {
+			for j := 0; j < len(alphabet); j++ {
+				if alphabet[j] == compact[i] {
+					continue
+				}
+
+				typo := append([]byte(nil), compact...)
+				typo[i] = alphabet[j]
+
+				_, err := HumanReadable.Decode(string(typo))
+				require.ErrorIs(t, err, ErrChecksumMismatch, string(typo))
+				assert.ErrorIs(t, err, ErrInvalidShortID)
+			}
+		}
+
+		// swapped neighbours are detected
+		for i := 0; i < len(compact)-1; i++ {
+			if compact[i] == compact[i+1] {
+				continue
+			}
+
+			typo := append([]byte(nil), compact...)
+			typo[i], typo[i+1] = typo[i+1], typo[i]
+
+			_, err := HumanReadable.Decode(string(typo))
+			assert.Error(t, err, string(typo))
+		}
+	}
+}
+
+func TestHumanReadableDecodeErrors(t *testing.T) {
+	sid := HumanReadable.Encode(uuid.Max)
+
+	testCases := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"invalid character", "U" + sid[1:], 0},
+		{"too short", sid[:len(sid)-1], -1},
+		{"too long", sid + "0", -1},
+		{"empty", "", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := HumanReadable.Decode(tc.input)
+			assert.ErrorIs(t, err, ErrInvalidShortID)
+			assert.NotErrorIs(t, err, ErrChecksumMismatch)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tc.position, perr.Position)
+		})
+	}
+
+	// a valid checksum over a value above 128 bits
+	overflow := []uint64{8}
+	for i := 0; i < 25; i++ {
+		overflow = append(overflow, 0)
+	}
+	input := "8" + strings.Repeat("0", 25) + string(Base32Crockford.(*alphabetEncoding).alphabet[luhnCheck(overflow, 32)])
+
+	_, err := HumanReadable.Decode(input)
+	assert.ErrorIs(t, err, ErrInvalidShortID)
+	assert.NotErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestNewChecksumEncoding(t *testing.T) {
+	enc, err := NewChecksumEncoding("0123456789", 0)
+	require.NoError(t, err)
+
+	uid := uuid.MustParse("00000000-0000-0000-0000-000000000007")
+	sid := enc.Encode(uid)
+	assert.Equal(t, strings.Repeat("0", 38)+"75", sid)
+
+	decoded, err := enc.Decode(sid)
+	require.NoError(t, err)
+	assert.Equal(t, uid, decoded)
+
+	_, err = enc.Decode(strings.Repeat("0", 38) + "76")
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	for _, tc := range []struct {
+		alphabet string
+		group    int
+	}{
+		{"0123-456789", 4},
+		{"0123 456789", 4},
+		{"0123456789", -1},
+		{"0", 4},
+	} {
+		_, err := NewChecksumEncoding(tc.alphabet, tc.group)
+		assert.ErrorIs(t, err, ErrInvalidAlphabet, tc.alphabet)
+	}
+}
+
+func TestHumanReadableShortID(t *testing.T) {
+	opts := []Option{WithShortEncoding(HumanReadable)}
+
+	sid, err := NewShortID("user@example.com", opts...)
+	require.NoError(t, err)
+
+	uid, err := NewUUID("user@example.com")
+	require.NoError(t, err)
+
+	parsed, err := ParseShortID(strings.ToLower(sid), opts...)
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	ok, err := Verify("user@example.com", sid, opts...)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	enc, err := LookupEncoding("human")
+	require.NoError(t, err)
+	assert.Equal(t, HumanReadable, enc)
+}
@@ -0,0 +1,360 @@
+package hashid
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+// RFC 5321 limits, in octets
+const (
+	maxEmailLength       = 254
+	maxLocalPartLength   = 64
+	maxDomainLength      = 253
+	maxDomainLabelLength = 63
+)
+
+// emailProvider holds the addressing rules of a mail provider
+type emailProvider struct {
+	// domain is the canonical domain, e.g. gmail.com for googlemail.com
+	domain string
+	// ignoreDots is set when dots in the local part are not significant
+	ignoreDots bool
+	// separators start a sub-address that is delivered to the same mailbox
+	separators string
+}
+
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, separators: "+"},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, separators: "+"},
+	"outlook.com":    {domain: "outlook.com", separators: "+"},
+	"hotmail.com":    {domain: "hotmail.com", separators: "+"},
+	"live.com":       {domain: "live.com", separators: "+"},
+	"icloud.com":     {domain: "icloud.com", separators: "+"},
+	"me.com":         {domain: "me.com", separators: "+"},
+	"fastmail.com":   {domain: "fastmail.com", separators: "+"},
+	"protonmail.com": {domain: "protonmail.com", separators: "+"},
+	"proton.me":      {domain: "proton.me", separators: "+"},
+}
+
+type emailOptions struct {
+	separators    string
+	providerRules bool
+	preserveCase  bool
+}
+
+// EmailOption configures NewEmailNormalizer.
+type EmailOption func(*emailOptions)
+
+// EmailSubaddress sets the characters that start a sub-address,
+// e.g. "jdoe+news@example.com", which is then removed for every
+// domain. By default only the provider rules remove sub-addresses,
+// since other domains may deliver them to different mailboxes.
+func EmailSubaddress(separators string) EmailOption {
+	return func(o *emailOptions) {
+		o.separators = separators
+	}
+}
+
+// EmailProviderRules enables the addressing rules of well known
+// providers, enabled by default: Gmail ignores dots in the local
+// part and googlemail.com is an alias of gmail.com, and providers
+// like Gmail, Outlook or iCloud deliver "+" sub-addresses to the
+// same mailbox.
+func EmailProviderRules(enabled bool) EmailOption {
+	return func(o *emailOptions) {
+		o.providerRules = enabled
+	}
+}
+
+// EmailPreserveCase keeps the case of the local part. RFC 5321
+// allows case sensitive local parts, but almost no server uses
+// them so they are lower cased by default.
+func EmailPreserveCase() EmailOption {
+	return func(o *emailOptions) {
+		o.preserveCase = true
+	}
+}
+
+// WithEmailNormalization normalizes input strings as email
+// addresses, see NewEmailNormalizer.
+//
+// Example:
+//
+//	id, _ := hashid.New("J.Doe+news@GoogleMail.com", hashid.WithEmailNormalization())
+//	// same ID as hashid.New("jdoe@gmail.com", hashid.WithEmailNormalization())
+//	id, _ = hashid.New("jdoe+news@example.com", hashid.WithEmailNormalization())
+//	// differs from hashid.New("jdoe@example.com", hashid.WithEmailNormalization())
+func WithEmailNormalization(opts ...EmailOption) Option {
+	return func(o *options) {
+		o.normalizer, o.normalizerErr = NewEmailNormalizer(opts...), nil
+	}
+}
+
+// EmailNormalizer canonicalizes an email address with the
+// default options of NewEmailNormalizer. It is registered as
+// the "email" normalizer.
+func EmailNormalizer(s string) (string, error) {
+	return defaultEmailNormalizer(s)
+}
+
+var defaultEmailNormalizer = NewEmailNormalizer()
+
+// NewEmailNormalizer returns a normalizer that canonicalizes email
+// addresses instead of applying the generic Normalizer, which strips
+// "@" and "." and makes unrelated addresses collide:
+//  1. trim leading/trailing spaces
+//  2. validate the local part following RFC 5321, quotes are
+//     removed when they are not needed
+//  3. lower case the local part, see EmailPreserveCase
+//  4. apply provider rules, which remove the sub-addresses of
+//     providers like Gmail, see EmailProviderRules
+//  5. remove sub-addresses of every domain, see EmailSubaddress
+//  6. lower case the domain and convert IDN domains to punycode
+//
+// Invalid addresses return a *NormalizationError wrapping
+// ErrInvalidEmail.
+func NewEmailNormalizer(opts ...EmailOption) func(string) (string, error) {
+	config := emailOptions{
+		providerRules: true,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(s string) (string, error) {
+		return normalizeEmail(s, config)
+	}
+}
+
+func normalizeEmail(input string, config emailOptions) (string, error) {
+	fail := func(position int, format string, args ...any) error {
+		return &NormalizationError{
+			Input:    input,
+			Position: position,
+			Err:      fmt.Errorf("%w: "+format, append([]any{ErrInvalidEmail}, args...)...),
+		}
+	}
+
+	offset := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+	s := strings.TrimSpace(input)
+
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return "", fail(-1, "missing @")
+	}
+
+	local, err := parseLocalPart(s[:at])
+	if err != nil {
+		if err.position >= 0 {
+			err.position += offset
+		}
+		return "", fail(err.position, "%s", err.msg)
+	}
+
+	domain, err := parseDomain(s[at+1:])
+	if err != nil {
+		if err.position >= 0 {
+			err.position += offset + at + 1
+		}
+		return "", fail(err.position, "%s", err.msg)
+	}
+
+	local.value = norm.NFC.String(local.value)
+	if !config.preserveCase {
+		local.value = strings.ToLower(local.value)
+	}
+
+	if !local.quoted {
+		separators := config.separators
+
+		provider, ok := emailProviders[domain]
+		if ok && config.providerRules {
+			domain = provider.domain
+			separators += provider.separators
+			if provider.ignoreDots {
+				local.value = strings.ReplaceAll(local.value, ".", "")
+			}
+		}
+
+		// keep the separator when it is the first character
+		if i := strings.IndexAny(local.value, separators); i > 0 {
+			local.value = strings.TrimSuffix(local.value[:i], ".")
+		}
+	}
+
+	if local.value == "" {
+		return "", fail(-1, "empty local part")
+	}
+
+	out := local.String() + "@" + domain
+	if len(out) > maxEmailLength {
+		return "", fail(-1, "address is longer than %d characters", maxEmailLength)
+	}
+	return out, nil
+}
+
+// emailError reports an invalid email at a position relative to
+// the part being parsed
+type emailError struct {
+	position int
+	msg      string
+}
+
+// localPart is a parsed local part, value is unquoted
+type localPart struct {
+	value  string
+	quoted bool
+}
+
+func (l localPart) String() string {
+	if !l.quoted {
+		return l.value
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range l.value {
+		if ch == '"' || ch == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(ch)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseLocalPart validates a dot-atom or quoted-string local part,
+// non ASCII characters are allowed as in RFC 6531
+func parseLocalPart(s string) (localPart, *emailError) {
+	if s == "" {
+		return localPart{}, &emailError{-1, "empty local part"}
+	}
+
+	if len(s) > maxLocalPartLength {
+		return localPart{}, &emailError{-1, fmt.Sprintf("local part is longer than %d characters", maxLocalPartLength)}
+	}
+
+	if !utf8.ValidString(s) {
+		return localPart{}, &emailError{-1, "local part is not valid UTF-8"}
+	}
+
+	if s[0] != '"' {
+		if err := validateDotAtom(s); err != nil {
+			return localPart{}, err
+		}
+		return localPart{value: s}, nil
+	}
+
+	var value strings.Builder
+	escaped := false
+	for i, ch := range s[1:] {
+		pos := i + 1
+		switch {
+		case escaped:
+			if ch < ' ' || ch > '~' {
+				return localPart{}, &emailError{pos, "invalid quoted character"}
+			}
+			value.WriteRune(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '"':
+			if pos != len(s)-1 {
+				return localPart{}, &emailError{pos, "unexpected quote"}
+			}
+		case ch < ' ' || ch == 0x7f:
+			return localPart{}, &emailError{pos, "invalid quoted character"}
+		default:
+			value.WriteRune(ch)
+		}
+	}
+
+	if len(s) < 2 || s[len(s)-1] != '"' || escaped {
+		return localPart{}, &emailError{-1, "unterminated quoted local part"}
+	}
+
+	// "jdoe"@example.com is the same address as jdoe@example.com
+	unquoted := value.String()
+	if unquoted != "" && validateDotAtom(unquoted) == nil {
+		return localPart{value: unquoted}, nil
+	}
+	return localPart{value: unquoted, quoted: true}, nil
+}
+
+// validateDotAtom checks a dot separated list of atext atoms
+func validateDotAtom(s string) *emailError {
+	for i, ch := range s {
+		if ch == '.' {
+			if i == 0 || i == len(s)-1 || s[i-1] == '.' {
+				return &emailError{i, "misplaced dot"}
+			}
+			continue
+		}
+
+		if !isAtext(ch) {
+			return &emailError{i, fmt.Sprintf("invalid character %q", ch)}
+		}
+	}
+	return nil
+}
+
+func isAtext(ch rune) bool {
+	switch {
+	case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+		return true
+	case ch >= utf8.RuneSelf:
+		return unicode.IsGraphic(ch) && !unicode.IsSpace(ch)
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", ch)
+}
+
+// parseDomain lower cases the domain and converts IDN
+// labels to punycode with the UTS #46 lookup profile,
+// address literals are kept
+func parseDomain(s string) (string, *emailError) {
+	if s == "" {
+		return "", &emailError{-1, "empty domain"}
+	}
+
+	if s[0] == '[' {
+		if s[len(s)-1] != ']' || strings.ContainsAny(s[1:len(s)-1], "[]\\ ") {
+			return "", &emailError{-1, "invalid address literal"}
+		}
+		return strings.ToLower(s), nil
+	}
+
+	domain, err := idna.Lookup.ToASCII(s)
+	if err != nil {
+		// positions are only exact when the mapping kept the length
+		pos := -1
+		if strings.IndexFunc(s, func(ch rune) bool { return ch >= utf8.RuneSelf }) < 0 {
+			pos = strings.IndexFunc(s, func(ch rune) bool { return !isDomainChar(ch) })
+		}
+		return "", &emailError{pos, err.Error()}
+	}
+	domain = strings.TrimSuffix(domain, ".")
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" {
+			return "", &emailError{-1, "empty domain label"}
+		}
+
+		if len(label) > maxDomainLabelLength {
+			return "", &emailError{-1, fmt.Sprintf("domain label is longer than %d characters", maxDomainLabelLength)}
+		}
+	}
+
+	if len(domain) > maxDomainLength {
+		return "", &emailError{-1, fmt.Sprintf("domain is longer than %d characters", maxDomainLength)}
+	}
+	return domain, nil
+}
+
+func isDomainChar(ch rune) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '.'
+}
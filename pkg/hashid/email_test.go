@@ -0,0 +1,149 @@
+package hashid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailNormalizer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lower case", "J.Doe@Example.COM", "j.doe@example.com"},
+		{"trim", "  jdoe@example.com\n", "jdoe@example.com"},
+		{"dots are kept", "j.doe@example.com", "j.doe@example.com"},
+		{"sub-address is kept", "jdoe+news@example.com", "jdoe+news@example.com"},
+		{"leading separator", "+jdoe@gmail.com", "+jdoe@gmail.com"},
+		{"separator after dot", "jdoe.+news@outlook.com", "jdoe@outlook.com"},
+		{"gmail dots", "J.Doe+News@gmail.com", "jdoe@gmail.com"},
+		{"googlemail", "j.d.o.e@GoogleMail.com", "jdoe@gmail.com"},
+		{"outlook", "jdoe+news@outlook.com", "jdoe@outlook.com"},
+		{"outlook keeps dots", "j.doe@outlook.com", "j.doe@outlook.com"},
+		{"unneeded quotes", `"j.doe"@example.com`, "j.doe@example.com"},
+		{"quoted", `"J Doe+x"@example.com`, `"j doe+x"@example.com`},
+		{"quoted pair", `"j\"doe"@example.com`, `"j\"doe"@example.com`},
+		{"quoted at", `"j@doe"@example.com`, `"j@doe"@example.com`},
+		{"special characters", "j!#$%&'*/=?^_`{|}~-doe@example.com", "j!#$%&'*/=?^_`{|}~-doe@example.com"},
+		{"unicode local part", "Jürgen@example.com", "jürgen@example.com"},
+		{"idn", "jdoe@Bücher.example", "jdoe@xn--bcher-kva.example"},
+		{"idn labels", "jdoe@例え。テスト", "jdoe@xn--r8jz45g.xn--zckzah"},
+		{"idn nfc", "jdoe@münchen.de", "jdoe@xn--mnchen-3ya.de"},
+		{"trailing dot", "jdoe@example.com.", "jdoe@example.com"},
+		{"address literal", "jdoe@[IPv6:2001:DB8::1]", "jdoe@[ipv6:2001:db8::1]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := EmailNormalizer(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestEmailNormalizerErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"missing at", "jdoe.example.com", -1},
+		{"empty local part", "@example.com", -1},
+		{"empty domain", "jdoe@", -1},
+		{"leading dot", ".jdoe@example.com", 0},
+		{"trailing dot", "jdoe.@example.com", 4},
+		{"double dot", " j..doe@example.com", 3},
+		{"space", "j doe@example.com", 1},
+		{"comma", "j,doe@example.com", 1},
+		{"unterminated quote", `"jdoe@example.com`, -1},
+		{"unescaped quote", `"j"doe"@example.com`, 2},
+		{"long local part", strings.Repeat("a", 65) + "@example.com", -1},
+		{"long label", "jdoe@" + strings.Repeat("a", 64) + ".com", -1},
+		{"long domain", "jdoe@" + strings.Repeat("a.", 127) + "com", -1},
+		{"domain character", "jdoe@exa_mple.com", 8},
+		{"hyphen", "jdoe@-example.com", -1},
+		{"empty label", "jdoe@example..com", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := EmailNormalizer(tc.input)
+			assert.ErrorIs(t, err, ErrInvalidEmail)
+
+			var nerr *NormalizationError
+			require.ErrorAs(t, err, &nerr)
+			assert.Equal(t, tc.input, nerr.Input)
+			assert.Equal(t, tc.position, nerr.Position)
+		})
+	}
+}
+
+func TestEmailOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []EmailOption
+		input    string
+		expected string
+	}{
+		{"all domains", []EmailOption{EmailSubaddress("+")}, "jdoe+news@example.com", "jdoe@example.com"},
+		{"all domains leading separator", []EmailOption{EmailSubaddress("+")}, "+jdoe@example.com", "+jdoe@example.com"},
+		{"provider sub-address", []EmailOption{EmailSubaddress("")}, "jdoe+news@gmail.com", "jdoe@gmail.com"},
+		{"custom separators", []EmailOption{EmailSubaddress("+-")}, "jdoe-news@example.com", "jdoe@example.com"},
+		{"custom separators and provider", []EmailOption{EmailSubaddress("-")}, "jdoe+news@gmail.com", "jdoe@gmail.com"},
+		{"no provider rules", []EmailOption{EmailProviderRules(false)}, "j.doe+news@googlemail.com", "j.doe+news@googlemail.com"},
+		{"no provider rules all domains", []EmailOption{EmailSubaddress("+"), EmailProviderRules(false)}, "j.doe+news@googlemail.com", "j.doe@googlemail.com"},
+		{"preserve case", []EmailOption{EmailPreserveCase()}, "JDoe+News@Example.com", "JDoe+News@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NewEmailNormalizer(tc.opts...)(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestWithEmailNormalization(t *testing.T) {
+	expected, err := New("jdoe@gmail.com", WithEmailNormalization())
+	require.NoError(t, err)
+
+	for _, input := range []string{"JDoe+news@gmail.com", "j.doe@googlemail.com", " J.D.O.E@Gmail.com "} {
+		id, err := New(input, WithEmailNormalization())
+		require.NoError(t, err)
+		assert.Equal(t, expected, id, input)
+	}
+
+	// the generic normalizer makes these collide
+	a, err := New("j.doe@x.com")
+	require.NoError(t, err)
+	b, err := New("jdoe@xcom")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	a, err = New("j.doe@x.com", WithEmailNormalization())
+	require.NoError(t, err)
+	b, err = New("jdoe@xcom", WithEmailNormalization())
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	_, err = New("not an email", WithEmailNormalization())
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+
+	n, err := LookupNormalizer("email")
+	require.NoError(t, err)
+	out, err := n("JDoe+news@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@gmail.com", out)
+
+	p, err := ParsePipeline("email")
+	require.NoError(t, err)
+	out, err = p.Normalize("JDoe+news@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@gmail.com", out)
+}
@@ -0,0 +1,285 @@
+package hashid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid"
+)
+
+// Encoding converts UUIDs to short IDs and back. Encoded
+// IDs always have the same length for a given encoding,
+// except Base57 which keeps the IDs of shortuuid.
+type Encoding interface {
+	// Encode returns the short ID of the UUID.
+	Encode(uid uuid.UUID) string
+	// Decode parses a short ID, errors are of type *ParseError.
+	Decode(sid string) (uuid.UUID, error)
+}
+
+var (
+	// Base57 is the default encoding, the IDs are those of
+	// github.com/lithammer/shortuuid: 13 to 22 characters,
+	// usually 22.
+	Base57 Encoding = base57{}
+
+	// Base57Padded uses the digits of Base57 but always pads
+	// IDs to 22 characters. Both encodings decode each other.
+	Base57Padded Encoding = base57{padded: true}
+
+	// Base58 uses the Bitcoin alphabet, without 0, O, I and l.
+	// IDs are 22 characters.
+	Base58 = mustEncoding("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// Base62 uses digits and upper and lower case letters,
+	// safe to use in URLs. IDs are 22 characters.
+	Base62 = mustEncoding("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+	// Base36 uses digits and lower case letters, decoding
+	// is case insensitive. IDs are 25 characters.
+	Base36 = mustEncoding("0123456789abcdefghijklmnopqrstuvwxyz", foldCase())
+
+	// Base32Crockford uses Douglas Crockford's base32 alphabet,
+	// meant to be read aloud. Decoding is case insensitive and
+	// maps I and L to 1 and O to 0. IDs are 26 characters.
+	Base32Crockford = mustEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+		foldCase(), alias('I', '1'), alias('L', '1'), alias('O', '0'))
+)
+
+var namedEncodings = map[string]Encoding{
+	"base57":        Base57,
+	"base57-padded": Base57Padded,
+	"base58":        Base58,
+	"base62":        Base62,
+	"base36":        Base36,
+	"crockford":     Base32Crockford,
+	"human":         HumanReadable,
+}
+
+// LookupEncoding returns the built-in encoding with the given
+// name: base57, base57-padded, base58, base62, base36,
+// crockford or human.
+func LookupEncoding(name string) (Encoding, error) {
+	enc, ok := namedEncodings[strings.ToLower(name)]
+	if !ok {
+		return nil, &ConfigError{Field: "encoding", Err: fmt.Errorf("%w: %s", ErrUnknownEncoding, name)}
+	}
+	return enc, nil
+}
+
+// WithShortEncoding sets the encoding used by NewShortID
+// and ParseShortID. The default is Base57.
+//
+// Example:
+//
+//	sid, _ := hashid.NewShortID("user@example.com",
+//		hashid.WithShortEncoding(hashid.Base62))
+//	uid, _ := hashid.ParseShortID(sid,
+//		hashid.WithShortEncoding(hashid.Base62))
+func WithShortEncoding(enc Encoding) Option {
+	return func(o *options) {
+		o.shortEncoding = enc
+	}
+}
+
+// NewEncoding creates an Encoding from a custom alphabet,
+// the first character is used for padding. The alphabet
+// must have at least 2 characters and no duplicates.
+func NewEncoding(alphabet string) (Encoding, error) {
+	return newAlphabetEncoding(alphabet)
+}
+
+// alphabetEncoding encodes the UUID as a big endian number
+// in the base of the alphabet, left padded to a fixed length
+type alphabetEncoding struct {
+	alphabet []rune
+	index    map[rune]uint64
+	base     uint64
+	length   int
+}
+
+type encodingOption func(*alphabetEncoding)
+
+// foldCase accepts both cases when decoding
+func foldCase() encodingOption {
+	return func(e *alphabetEncoding) {
+		for _, ch := range e.alphabet {
+			e.index[unicode.ToLower(ch)] = e.index[ch]
+			e.index[unicode.ToUpper(ch)] = e.index[ch]
+		}
+	}
+}
+
+// alias decodes from as to
+func alias(from, to rune) encodingOption {
+	return func(e *alphabetEncoding) {
+		e.index[from] = e.index[to]
+		e.index[unicode.ToLower(from)] = e.index[to]
+	}
+}
+
+func newAlphabetEncoding(alphabet string, opts ...encodingOption) (*alphabetEncoding, error) {
+	if !utf8.ValidString(alphabet) {
+		return nil, encodingError(fmt.Errorf("%w: alphabet is not valid UTF-8", ErrInvalidAlphabet))
+	}
+
+	e := &alphabetEncoding{
+		alphabet: []rune(alphabet),
+		index:    make(map[rune]uint64, len(alphabet)),
+	}
+
+	if len(e.alphabet) < 2 {
+		return nil, encodingError(fmt.Errorf("%w: at least 2 characters are required", ErrInvalidAlphabet))
+	}
+
+	for i, ch := range e.alphabet {
+		if _, ok := e.index[ch]; ok {
+			return nil, encodingError(fmt.Errorf("%w: duplicated character %q", ErrInvalidAlphabet, ch))
+		}
+		e.index[ch] = uint64(i)
+	}
+
+	e.base = uint64(len(e.alphabet))
+
+	// smallest length that can hold 128 bits
+	hi, lo := uint64(0), uint64(1)
+	for overflow := false; !overflow; e.length++ {
+		hi, lo, overflow = mulAdd(hi, lo, e.base, 0)
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+func mustEncoding(alphabet string, opts ...encodingOption) Encoding {
+	e, err := newAlphabetEncoding(alphabet, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *alphabetEncoding) Encode(uid uuid.UUID) string {
+	hi := binary.BigEndian.Uint64(uid[:8])
+	lo := binary.BigEndian.Uint64(uid[8:])
+
+	out := make([]rune, e.length)
+	for i := e.length - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, e.base)
+		lo, rem = bits.Div64(rem, lo, e.base)
+		out[i] = e.alphabet[rem]
+	}
+	return string(out)
+}
+
+func (e *alphabetEncoding) Decode(sid string) (uuid.UUID, error) {
+	var hi, lo uint64
+
+	n := 0
+	for i, ch := range sid {
+		digit, ok := e.index[ch]
+		if !ok {
+			return uuid.Nil, &ParseError{Input: sid, Position: i, Err: ErrInvalidShortID}
+		}
+
+		var overflow bool
+		hi, lo, overflow = mulAdd(hi, lo, e.base, digit)
+		if overflow {
+			return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+		}
+		n++
+	}
+
+	if n != e.length {
+		return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+	}
+
+	var uid uuid.UUID
+	binary.BigEndian.PutUint64(uid[:8], hi)
+	binary.BigEndian.PutUint64(uid[8:], lo)
+	return uid, nil
+}
+
+// mulAdd returns (hi, lo) * m + a as a 128 bit number,
+// and whether the result overflowed
+func mulAdd(hi, lo, m, a uint64) (uint64, uint64, bool) {
+	carry, hi := bits.Mul64(hi, m)
+	if carry != 0 {
+		return 0, 0, true
+	}
+
+	h, lo := bits.Mul64(lo, m)
+	hi, c := bits.Add64(hi, h, 0)
+	if c != 0 {
+		return 0, 0, true
+	}
+
+	lo, c = bits.Add64(lo, a, 0)
+	hi, c = bits.Add64(hi, 0, c)
+	return hi, lo, c != 0
+}
+
+// base57 encodes least significant digit first like
+// github.com/lithammer/shortuuid, which only pads IDs to 13
+// characters. Padded IDs always have 22 characters, shortuuid
+// drops trailing padding so shorter IDs are accepted by both.
+type base57 struct {
+	padded bool
+}
+
+var base57Padded = base57{padded: true}
+
+var base57Digits = mustEncoding(shortuuid.DefaultAlphabet).(*alphabetEncoding)
+
+// base57MinLength is the shortest ID produced by shortuuid
+const base57MinLength = 13
+
+func (b base57) Encode(uid uuid.UUID) string {
+	if !b.padded {
+		return shortuuid.DefaultEncoder.Encode(uid)
+	}
+	return reverse(base57Digits.Encode(uid))
+}
+
+func (base57) Decode(sid string) (uuid.UUID, error) {
+	for i, ch := range sid {
+		if _, ok := base57Digits.index[ch]; !ok {
+			return uuid.Nil, &ParseError{Input: sid, Position: i, Err: ErrInvalidShortID}
+		}
+	}
+
+	// all characters are ASCII at this point
+	if len(sid) < base57MinLength || len(sid) > base57Digits.length {
+		return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+	}
+
+	// values that do not fit in 128 bits fail to decode,
+	// or to encode back to the same digits
+	padded := sid + strings.Repeat(string(base57Digits.alphabet[0]), base57Digits.length-len(sid))
+	uid, err := base57Digits.Decode(reverse(padded))
+	if err != nil || base57Padded.Encode(uid) != padded {
+		return uuid.Nil, &ParseError{Input: sid, Position: -1, Err: ErrInvalidShortID}
+	}
+	return uid, nil
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func encodingError(err error) error {
+	return &ConfigError{Field: "encoding", Err: err}
+}
@@ -0,0 +1,261 @@
+package hashid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referenceEncode encodes uid using math/big
+func referenceEncode(uid uuid.UUID, alphabet string, length int) string {
+	chars := []rune(alphabet)
+	base := big.NewInt(int64(len(chars)))
+	n := new(big.Int).SetBytes(uid[:])
+
+	out := make([]rune, length)
+	for i := length - 1; i >= 0; i-- {
+		rem := new(big.Int)
+		n.DivMod(n, base, rem)
+		out[i] = chars[rem.Int64()]
+	}
+	return string(out)
+}
+
+func testUUIDs(t *testing.T) []uuid.UUID {
+	uids := []uuid.UUID{
+		uuid.Nil,
+		uuid.Max,
+		uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		uuid.MustParse("ffffffff-ffff-ffff-0000-000000000000"),
+	}
+
+	for _, input := range []string{"user@example.com", "a", "b", "c", "d"} {
+		uid, err := NewUUID(input)
+		require.NoError(t, err)
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+func TestEncodings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		enc      Encoding
+		alphabet string
+		length   int
+	}{
+		{"base58", Base58, "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", 22},
+		{"base62", Base62, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", 22},
+		{"base36", Base36, "0123456789abcdefghijklmnopqrstuvwxyz", 25},
+		{"crockford", Base32Crockford, "0123456789ABCDEFGHJKMNPQRSTVWXYZ", 26},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, uid := range testUUIDs(t) {
+				sid := tc.enc.Encode(uid)
+				assert.Len(t, sid, tc.length)
+				assert.Equal(t, referenceEncode(uid, tc.alphabet, tc.length), sid)
+
+				decoded, err := tc.enc.Decode(sid)
+				require.NoError(t, err)
+				assert.Equal(t, uid, decoded)
+			}
+		})
+	}
+}
+
+func TestBase57Compatibility(t *testing.T) {
+	for _, uid := range testUUIDs(t) {
+		assert.Equal(t, shortuuid.DefaultEncoder.Encode(uid), Base57.Encode(uid), uid)
+
+		sid := Base57Padded.Encode(uid)
+		assert.Len(t, sid, 22)
+
+		decoded, err := Base57Padded.Decode(sid)
+		require.NoError(t, err)
+		assert.Equal(t, uid, decoded)
+
+		// shortuuid decodes padded IDs
+		decoded, err = shortuuid.DefaultEncoder.Decode(sid)
+		require.NoError(t, err)
+		assert.Equal(t, uid, decoded)
+	}
+
+	// shortuuid does not pad values below 57^21
+	uid := uuid.MustParse("00000000-0000-0000-0000-000000000fff")
+	legacy := Base57.Encode(uid)
+	assert.Len(t, legacy, 13)
+
+	decoded, err := Base57Padded.Decode(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, uid, decoded)
+}
+
+func TestBase57ShortIDs(t *testing.T) {
+	sid, err := NewShortID("user62@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "YLWxAWwPiebgLDQYYCesA", sid)
+
+	gen, err := NewGenerator()
+	require.NoError(t, err)
+
+	short := 0
+	for i := 0; i < 20000; i++ {
+		uid, err := gen.NewUUID(fmt.Sprintf("user%d@example.com", i))
+		require.NoError(t, err)
+
+		sid := Base57.Encode(uid)
+		require.Equal(t, shortuuid.DefaultEncoder.Encode(uid), sid, uid)
+		if len(sid) == 22 {
+			continue
+		}
+		short++
+
+		decoded, err := Base57.Decode(sid)
+		require.NoError(t, err, sid)
+		assert.Equal(t, uid, decoded, sid)
+
+		// the padded form decodes to the same UUID
+		padded := Base57Padded.Encode(uid)
+		assert.True(t, strings.HasPrefix(padded, sid), sid)
+	}
+
+	// about 1 in 57 IDs has a most significant digit of 0
+	assert.Greater(t, short, 200)
+}
+
+func TestEncodingDecodeErrors(t *testing.T) {
+	max := Base62.Encode(uuid.Max)
+
+	testCases := []struct {
+		name     string
+		enc      Encoding
+		input    string
+		position int
+	}{
+		{"invalid character", Base62, "0000000000000000000-00", 19},
+		{"too short", Base62, max[1:], -1},
+		{"too long", Base62, "0" + max, -1},
+		{"overflow", Base62, strings.Repeat("z", 22), -1},
+		{"crockford U", Base32Crockford, strings.Repeat("0", 25) + "U", 25},
+		{"base58 zero", Base58, strings.Repeat("1", 21) + "0", 21},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.enc.Decode(tc.input)
+			assert.ErrorIs(t, err, ErrInvalidShortID)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tc.position, perr.Position)
+		})
+	}
+}
+
+func TestCrockfordDecoding(t *testing.T) {
+	uid, err := NewUUID("user@example.com")
+	require.NoError(t, err)
+
+	sid := Base32Crockford.Encode(uid)
+
+	decoded, err := Base32Crockford.Decode(strings.ToLower(sid))
+	require.NoError(t, err)
+	assert.Equal(t, uid, decoded)
+
+	// I and L read as 1, O as 0
+	decoded, err = Base32Crockford.Decode(strings.Repeat("0", 24) + "O1")
+	require.NoError(t, err)
+	expected, err := Base32Crockford.Decode(strings.Repeat("0", 24) + "01")
+	require.NoError(t, err)
+	assert.Equal(t, expected, decoded)
+
+	for _, s := range []string{"I", "i", "L", "l"} {
+		decoded, err = Base32Crockford.Decode(strings.Repeat("0", 25) + s)
+		require.NoError(t, err)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), decoded)
+	}
+}
+
+func TestNewEncoding(t *testing.T) {
+	enc, err := NewEncoding("01")
+	require.NoError(t, err)
+
+	sid := enc.Encode(uuid.Max)
+	assert.Equal(t, strings.Repeat("1", 128), sid)
+
+	// non ASCII alphabets are supported
+	enc, err = NewEncoding("αβγδεζηθ")
+	require.NoError(t, err)
+
+	for _, uid := range testUUIDs(t) {
+		decoded, err := enc.Decode(enc.Encode(uid))
+		require.NoError(t, err)
+		assert.Equal(t, uid, decoded)
+	}
+
+	for _, alphabet := range []string{"", "a", "abca", "ab\xff"} {
+		_, err := NewEncoding(alphabet)
+		assert.ErrorIs(t, err, ErrInvalidAlphabet, alphabet)
+	}
+}
+
+func TestWithShortEncoding(t *testing.T) {
+	opts := []Option{WithHashAlgorithm(SHA1), WithShortEncoding(Base62)}
+
+	sid, err := NewShortID("user@example.com", opts...)
+	require.NoError(t, err)
+	assert.Len(t, sid, 22)
+
+	uid, err := NewUUID("user@example.com", opts...)
+	require.NoError(t, err)
+	assert.Equal(t, Base62.Encode(uid), sid)
+
+	parsed, err := ParseShortID(sid, WithShortEncoding(Base62))
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	ok, err := Verify("user@example.com", sid, opts...)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	gen, err := NewGenerator(opts...)
+	require.NoError(t, err)
+	parsed, err = gen.ParseShortID(sid)
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	_, err = NewGenerator(WithShortEncoding(nil))
+	assert.ErrorIs(t, err, ErrUnknownEncoding)
+}
+
+func TestLookupEncoding(t *testing.T) {
+	for name, expected := range map[string]Encoding{
+		"base57":        Base57,
+		"base57-padded": Base57Padded,
+		"Base62":        Base62,
+		"crockford":     Base32Crockford,
+	} {
+		enc, err := LookupEncoding(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, enc)
+	}
+
+	_, err := LookupEncoding("base64")
+	assert.ErrorIs(t, err, ErrUnknownEncoding)
+}
+
+func BenchmarkBase62Encode(b *testing.B) {
+	uid := uuid.MustParse("ddea575a-d5e2-3114-9267-dbead79c4ab8")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Base62.Encode(uid)
+	}
+}
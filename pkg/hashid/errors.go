@@ -0,0 +1,147 @@
+package hashid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnsupportedAlgorithm is returned when the hashing
+	// algorithm is not known.
+	ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+
+	// ErrUnsupportedVersion is returned when the UUID version
+	// is not one of 3, 5 or 8.
+	ErrUnsupportedVersion = errors.New("unsupported UUID version")
+
+	// ErrMissingKey is returned when a keyed algorithm is
+	// used without an HMAC key.
+	ErrMissingKey = errors.New("missing HMAC key")
+
+	// ErrInvalidKey is returned when an HMAC key is given
+	// for an algorithm that does not use a key.
+	ErrInvalidKey = errors.New("invalid HMAC key")
+
+	// ErrVersionAlgorithmMismatch is returned in strict mode
+	// when the UUID version does not match the algorithm,
+	// e.g. MD5 with version 5.
+	ErrVersionAlgorithmMismatch = errors.New("UUID version does not match hash algorithm")
+
+	// ErrInvalidCharMap is returned when a character map
+	// can not be loaded or used for normalization.
+	ErrInvalidCharMap = errors.New("invalid character map")
+
+	// ErrUnknownNormalizer is returned when no normalizer
+	// is registered with the requested name.
+	ErrUnknownNormalizer = errors.New("unknown normalizer")
+
+	// ErrInvalidEmail is returned by the email normalizer
+	// when the input is not a valid email address.
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrInvalidURL is returned by the URL and domain
+	// normalizers when the input can not be normalized.
+	ErrInvalidURL = errors.New("invalid URL")
+
+	// ErrInvalidPhone is returned by the phone normalizer
+	// when the input is not a valid phone number.
+	ErrInvalidPhone = errors.New("invalid phone number")
+
+	// ErrUnknownRegion is returned when a phone normalizer
+	// is created for an unsupported region.
+	ErrUnknownRegion = errors.New("unknown region")
+
+	// ErrUnknownCaseFolding is returned when a case
+	// folding mode is not lower, simple or full.
+	ErrUnknownCaseFolding = errors.New("unknown case folding mode")
+
+	// ErrInvalidPipeline is returned when a normalization
+	// pipeline spec can not be parsed.
+	ErrInvalidPipeline = errors.New("invalid pipeline")
+
+	// ErrUnknownEncoding is returned when no short ID
+	// encoding exists with the requested name.
+	ErrUnknownEncoding = errors.New("unknown encoding")
+
+	// ErrInvalidAlphabet is returned when a custom short
+	// ID alphabet can not be used.
+	ErrInvalidAlphabet = errors.New("invalid alphabet")
+
+	// ErrInvalidShortID is returned when a short ID can
+	// not be decoded.
+	ErrInvalidShortID = errors.New("invalid short ID")
+
+	// ErrChecksumMismatch is returned, along with
+	// ErrInvalidShortID, when the check character of a
+	// checksummed short ID does not match, usually
+	// because of a transcription error.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrNoParts is returned when a composite key has
+	// no parts.
+	ErrNoParts = errors.New("at least one part is required")
+
+	// ErrInvalidID is returned when an ID is neither a
+	// UUID nor a short ID.
+	ErrInvalidID = errors.New("invalid ID")
+
+	// ErrNoMatchingKey is returned by Keyring.Match when none
+	// of the active keys produced the given ID.
+	ErrNoMatchingKey = errors.New("no active key matches the ID")
+)
+
+// ConfigError reports an invalid option. Field names the
+// offending setting, e.g. "algorithm", "version" or "charmap",
+// and Err holds one of the sentinel errors of the package.
+type ConfigError struct {
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// NormalizationError reports an input that could not be
+// normalized. Position is the byte offset in Input where
+// the problem was found, or -1 when it is not known.
+type NormalizationError struct {
+	Input    string
+	Position int
+	Err      error
+}
+
+func (e *NormalizationError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("normalization error: %v", e.Err)
+	}
+	return fmt.Sprintf("normalization error at position %d: %v", e.Position, e.Err)
+}
+
+func (e *NormalizationError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError reports an ID that could not be parsed.
+// Position is the byte offset in Input of the offending
+// character, or -1 when the ID is invalid as a whole.
+type ParseError struct {
+	Input    string
+	Position int
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("%v %q", e.Err, e.Input)
+	}
+	return fmt.Sprintf("%v %q: unexpected character at position %d", e.Err, e.Input, e.Position)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,109 @@
+package hashid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option
+		field   string
+		target  error
+	}{
+		{"unknown algorithm", []Option{WithHashAlgorithm("unknown")}, "algorithm", ErrUnsupportedAlgorithm},
+		{"invalid version", []Option{WithUUIDVersion(10)}, "version", ErrUnsupportedVersion},
+		{"missing key", []Option{WithHashAlgorithm(HMAC_SHA256)}, "key", ErrMissingKey},
+		{"strict version", []Option{WithUUIDVersion(5), WithStrictVersion()}, "version", ErrVersionAlgorithmMismatch},
+		{"fingerprint", []Option{WithEmbeddedFingerprint()}, "fingerprint", ErrUnsupportedVersion},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New("user@example.com", tc.options...)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.target)
+
+			var cerr *ConfigError
+			require.ErrorAs(t, err, &cerr)
+			assert.Equal(t, tc.field, cerr.Field)
+		})
+	}
+}
+
+func TestNormalizationErrors(t *testing.T) {
+	failure := errors.New("failure")
+
+	_, err := New("user@example.com", WithCustomNormalizer(func(string) (string, error) {
+		return "", failure
+	}))
+
+	var nerr *NormalizationError
+	require.ErrorAs(t, err, &nerr)
+	assert.Equal(t, "user@example.com", nerr.Input)
+	assert.Equal(t, -1, nerr.Position)
+	assert.ErrorIs(t, err, failure)
+
+	// the position reported by the normalizer is kept
+	_, err = New("user@example.com", WithCustomNormalizer(func(s string) (string, error) {
+		return "", &NormalizationError{Input: s, Position: 4, Err: failure}
+	}))
+	require.ErrorAs(t, err, &nerr)
+	assert.Equal(t, 4, nerr.Position)
+	assert.Equal(t, "normalization error at position 4: failure", err.Error())
+}
+
+func TestCharMapErrors(t *testing.T) {
+	_, err := loadCharMap([]byte(`{"a": "b",}`))
+	assert.ErrorIs(t, err, ErrInvalidCharMap)
+
+	var cerr *ConfigError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "charmap", cerr.Field)
+
+	var serr *json.SyntaxError
+	require.ErrorAs(t, err, &serr)
+	assert.Equal(t, int64(11), serr.Offset)
+
+	err = ValidateCharMap(map[string]string{"ab": "c"})
+	assert.ErrorIs(t, err, ErrInvalidCharMap)
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "charmap", cerr.Field)
+
+	_, err = LookupNormalizer("unknown")
+	assert.ErrorIs(t, err, ErrUnknownNormalizer)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"invalid character", "2M9JFnemic9bLiXnT8AHu0", 21},
+		{"too short", "2M9JFnemic9b", -1},
+		{"too long", "2M9JFnemic9bLiXnT8AHunn", -1},
+		{"overflow", "zzzzzzzzzzzzzzzzzzzzzz", -1},
+		{"empty", "", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseShortID(tc.input)
+			assert.ErrorIs(t, err, ErrInvalidShortID)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tc.input, perr.Input)
+			assert.Equal(t, tc.position, perr.Position)
+		})
+	}
+
+	_, err := ParseID("not-an-id")
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
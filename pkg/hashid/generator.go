@@ -0,0 +1,313 @@
+package hashid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Generator produces deterministic identifiers using a fixed
+// set of options. Options are applied and validated once, when
+// the Generator is created, so it is the preferred way to
+// generate large amounts of IDs with the same configuration.
+//
+// A Generator is safe for concurrent use.
+//
+// Example:
+//
+//	gen, err := hashid.NewGenerator(hashid.WithHashAlgorithm(hashid.SHA1))
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	id, err := gen.New("user@example.com")
+type Generator struct {
+	config     options
+	version    int
+	normalizer func(string) (string, error)
+	newHasher  func() (hash.Hash, error)
+	hashers    sync.Pool
+	tag        []byte
+	warnings   []error
+}
+
+// NewGenerator creates a Generator from the provided options.
+// Configuration errors are returned here instead of on each
+// call to New.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	config := defaultOptions()
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	// the pipeline and charmap take precedence over the normalizer
+	if config.normalizerErr != nil && config.pipeline == nil && config.charMap == nil {
+		return nil, config.normalizerErr
+	}
+
+	if _, ok := caseFoldingNames[config.caseFolding]; !ok {
+		return nil, caseFoldingError(config.caseFolding.String())
+	}
+
+	// a key selects HMAC_SHA256 unless an algorithm was set
+	if config.hmacKey != nil && !config.hashAlgoSet {
+		config.hashAlgo = HMAC_SHA256
+	}
+
+	algo, ok := lookupAlgorithm(config.hashAlgo)
+	if !ok {
+		return nil, &ConfigError{Field: "algorithm", Err: fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, config.hashAlgo)}
+	}
+
+	if config.hmacKey != nil && !algo.keyed {
+		return nil, &ConfigError{Field: "key", Err: fmt.Errorf("%w: %s does not use a key", ErrInvalidKey, config.hashAlgo)}
+	}
+
+	version, err := resolveVersion(config, algo)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []error
+	if err := checkVersion(config, algo, version); err != nil {
+		if config.strictVersion {
+			return nil, err
+		}
+		warnings = append(warnings, err)
+	}
+
+	if config.shortEncoding == nil {
+		return nil, encodingError(fmt.Errorf("%w: encoding is nil", ErrUnknownEncoding))
+	}
+
+	if config.fingerprint && version != 8 {
+		return nil, &ConfigError{
+			Field: "fingerprint",
+			Err:   fmt.Errorf("%w: embedding requires version 8, got %d", ErrUnsupportedVersion, version),
+		}
+	}
+
+	normalizer := config.normalizer
+	switch {
+	case config.pipeline != nil:
+		normalizer = config.pipeline.Normalize
+	case config.charMap != nil || normalizer == nil:
+		n, err := newNormalizer(config.charMap, "-")
+		if err != nil {
+			return nil, err
+		}
+		if step, ok := config.caseStep(); ok {
+			n.pipeline = withCaseStep(n.pipeline, step)
+		}
+		normalizer = n.normalize
+	}
+
+	newHasher := func() (hash.Hash, error) {
+		h, err := algo.factory(config.hmacKey)
+		if err == nil && h == nil {
+			err = fmt.Errorf("%w: %s factory returned no hasher", ErrUnsupportedAlgorithm, config.hashAlgo)
+		}
+		if err != nil {
+			field := "algorithm"
+			if errors.Is(err, ErrMissingKey) {
+				field = "key"
+			}
+			return nil, &ConfigError{Field: field, Err: err}
+		}
+		return h, nil
+	}
+
+	// validate the hasher configuration before we hand it to the pool
+	h, err := newHasher()
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Size() < 16 {
+		return nil, &ConfigError{
+			Field: "algorithm",
+			Err:   fmt.Errorf("%w: %s digest is %d bytes, at least 16 are required", ErrUnsupportedAlgorithm, config.hashAlgo, h.Size()),
+		}
+	}
+
+	g := &Generator{
+		config:     config,
+		version:    version,
+		normalizer: normalizer,
+		newHasher:  newHasher,
+		warnings:   warnings,
+	}
+	g.hashers.Put(h)
+
+	if config.fingerprint {
+		g.tag = g.fingerprint()[:fingerprintTagSize]
+	}
+
+	return g, nil
+}
+
+// New generates a UUID string from the provided input string.
+func (g *Generator) New(input string) (string, error) {
+	uid, err := g.NewUUID(input)
+	if err != nil {
+		return "", err
+	}
+	return uid.String(), nil
+}
+
+// NewUUID generates a uuid.UUID from the provided input string.
+func (g *Generator) NewUUID(input string) (uuid.UUID, error) {
+	input, err := g.Normalize(input)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return g.hash([]byte(input))
+}
+
+// NewShortID generates a short ID from the provided
+// input string, using the configured encoding.
+func (g *Generator) NewShortID(input string) (string, error) {
+	uid, err := g.NewUUID(input)
+	if err != nil {
+		return "", err
+	}
+	return g.config.shortEncoding.Encode(uid), nil
+}
+
+// ParseShortID decodes a short ID using the configured encoding.
+func (g *Generator) ParseShortID(sid string) (uuid.UUID, error) {
+	return g.config.shortEncoding.Decode(sid)
+}
+
+// Normalize returns the input as it will be hashed,
+// i.e. after applying the configured normalizer.
+func (g *Generator) Normalize(input string) (string, error) {
+	if !g.config.normalize {
+		return input, nil
+	}
+
+	out, err := g.normalizer(input)
+	if err != nil {
+		// keep the position reported by the normalizer
+		var nerr *NormalizationError
+		if errors.As(err, &nerr) {
+			return "", err
+		}
+		return "", &NormalizationError{Input: input, Position: -1, Err: err}
+	}
+	return out, nil
+}
+
+// Algorithm returns the configured hashing algorithm.
+func (g *Generator) Algorithm() HashAlgorithm {
+	return g.config.hashAlgo
+}
+
+// Version returns the version of the generated UUIDs.
+func (g *Generator) Version() int {
+	return g.version
+}
+
+// Warnings returns the configuration issues that did not
+// prevent creating the Generator, such as a UUID version
+// that does not match the algorithm.
+func (g *Generator) Warnings() []error {
+	return append([]error(nil), g.warnings...)
+}
+
+// Fingerprint returns a short identifier of the configuration,
+// two generators with the same fingerprint produce the same IDs.
+// The HMAC key is not exposed, only a keyed digest of it.
+//
+// Custom normalizer functions can not be fingerprinted, they
+// are all represented as "custom". Pipeline steps are identified
+// by their spec, custom steps by their name.
+func (g *Generator) Fingerprint() string {
+	return hex.EncodeToString(g.fingerprint()[:8])
+}
+
+func (g *Generator) fingerprint() []byte {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "algorithm=%s;version=%d;normalize=%t;", g.config.hashAlgo, g.version, g.config.normalize)
+
+	if g.config.fingerprint {
+		h.Write([]byte("fingerprint=embedded;"))
+	}
+
+	if g.config.namespace != nil {
+		fmt.Fprintf(h, "namespace=%s;", g.config.namespace)
+	}
+
+	switch {
+	case g.config.pipeline != nil:
+		// the default pipeline produces the same IDs as the default normalizer
+		if spec := g.config.pipeline.String(); spec != DefaultPipeline().String() {
+			fmt.Fprintf(h, "pipeline=%s;", spec)
+		}
+	case g.config.charMap != nil:
+		keys := make([]string, 0, len(g.config.charMap))
+		for k := range g.config.charMap {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		h.Write([]byte("charmap="))
+		for _, k := range keys {
+			h.Write([]byte(strconv.Quote(k) + ":" + strconv.Quote(g.config.charMap[k]) + ","))
+		}
+		h.Write([]byte(";"))
+	case g.config.normalizer != nil:
+		h.Write([]byte("normalizer=custom;"))
+	}
+
+	// case options only apply to the default normalizer, where they
+	// change the characters that are not in the charmap
+	if step, ok := g.config.caseStep(); ok && g.config.pipeline == nil &&
+		(g.config.charMap != nil || g.config.normalizer == nil) {
+		fmt.Fprintf(h, "%s;", step)
+	}
+
+	if g.config.hmacKey != nil {
+		mac := hmac.New(sha256.New, g.config.hmacKey)
+		mac.Write([]byte("hashid-fingerprint"))
+		fmt.Fprintf(h, "key=%x;", mac.Sum(nil))
+	}
+
+	return h.Sum(nil)
+}
+
+// hash returns the UUID of data, the pool is refilled with the
+// factory which can fail even though it succeeded in NewGenerator
+func (g *Generator) hash(data []byte) (uuid.UUID, error) {
+	hasher, ok := g.hashers.Get().(hash.Hash)
+	if !ok {
+		var err error
+		if hasher, err = g.newHasher(); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	defer g.hashers.Put(hasher)
+
+	hasher.Reset()
+	if g.config.namespace != nil {
+		hasher.Write(g.config.namespace[:])
+	}
+	hasher.Write(data)
+
+	var sum [64]byte
+	uid := formatUUID(hasher.Sum(sum[:0]), g.version)
+	if g.tag != nil {
+		copy(uid[16-fingerprintTagSize:], g.tag)
+	}
+	return uid, nil
+}
@@ -0,0 +1,158 @@
+package hashid
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratorMatchesNew(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		options []Option
+	}{
+		{"Default MD5", "A81758FFFE04©E4F5", nil},
+		{"SHA1", "user@example.com", []Option{WithHashAlgorithm(SHA1)}},
+		{"SHA256", "user@example.com", []Option{WithHashAlgorithm(SHA256)}},
+		{"HMAC-SHA256", "user@example.com", []Option{WithHMACKey([]byte("secret"))}},
+		{"No normalization", "  Leading And Trailing  ", []Option{WithNormalization(false)}},
+		{"Custom char map", "test@example.com", []Option{WithCustomCharMap(map[string]string{"@": "at"})}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen, err := NewGenerator(tc.options...)
+			require.NoError(t, err)
+
+			expected, err := New(tc.input, tc.options...)
+			require.NoError(t, err)
+
+			id, err := gen.New(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, expected, id)
+
+			uid, err := gen.NewUUID(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, expected, uid.String())
+
+			sid, err := gen.NewShortID(tc.input)
+			require.NoError(t, err)
+			parsed, err := ParseShortID(sid)
+			require.NoError(t, err)
+			assert.Equal(t, uid, parsed)
+		})
+	}
+}
+
+func TestNewGeneratorInvalidConfigurations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option
+	}{
+		{"HMAC without key", []Option{WithHashAlgorithm(HMAC_SHA256)}},
+		{"Invalid UUID version", []Option{WithUUIDVersion(10)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen, err := NewGenerator(tc.options...)
+			assert.Error(t, err)
+			assert.Nil(t, gen)
+		})
+	}
+}
+
+func TestGeneratorConcurrentAccess(t *testing.T) {
+	gen, err := NewGenerator(WithHMACKey([]byte("secret")))
+	require.NoError(t, err)
+
+	expected, err := gen.NewUUID("test")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uid, err := gen.NewUUID("test")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, uid)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGeneratorCustomNormalizer(t *testing.T) {
+	gen, err := NewGenerator(WithCustomNormalizer(func(s string) (string, error) {
+		return "constant", nil
+	}))
+	require.NoError(t, err)
+
+	a, err := gen.NewUUID("first")
+	require.NoError(t, err)
+	b, err := gen.NewUUID("second")
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.Equal(t, uuid.Version(3), a.Version())
+}
+
+func BenchmarkGeneratorNewUUID(b *testing.B) {
+	gen, err := NewGenerator()
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gen.NewUUID("user@example.com")
+	}
+}
+
+func BenchmarkNewUUID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewUUID("user@example.com")
+	}
+}
+
+func TestGeneratorAccessors(t *testing.T) {
+	gen, err := NewGenerator(WithHashAlgorithm(SHA1))
+	require.NoError(t, err)
+
+	assert.Equal(t, SHA1, gen.Algorithm())
+	assert.Equal(t, 5, gen.Version())
+
+	normalized, err := gen.Normalize("  User@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "userexamplecom", normalized)
+
+	raw, err := NewGenerator(WithNormalization(false))
+	require.NoError(t, err)
+	normalized, err = raw.Normalize("  User@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "  User@Example.com ", normalized)
+}
+
+func TestGeneratorFingerprint(t *testing.T) {
+	fingerprint := func(opts ...Option) string {
+		gen, err := NewGenerator(opts...)
+		require.NoError(t, err)
+		return gen.Fingerprint()
+	}
+
+	assert.Len(t, fingerprint(), 16)
+	assert.Equal(t, fingerprint(), fingerprint())
+	assert.Equal(t, fingerprint(WithHashAlgorithm(SHA1)), fingerprint(WithHashAlgorithm(SHA1)))
+
+	assert.NotEqual(t, fingerprint(), fingerprint(WithHashAlgorithm(SHA1)))
+	assert.NotEqual(t, fingerprint(), fingerprint(WithNormalization(false)))
+	assert.NotEqual(t, fingerprint(), fingerprint(WithNamespace(NamespaceDNS)))
+	assert.NotEqual(t, fingerprint(), fingerprint(WithCustomCharMap(map[string]string{"@": "at"})))
+	assert.NotEqual(t,
+		fingerprint(WithHMACKey([]byte("a"))),
+		fingerprint(WithHMACKey([]byte("b"))))
+}
@@ -1,15 +1,10 @@
 package hashid
 
 import (
-	"crypto/hmac"
-	"crypto/md5"
-	"crypto/sha1"
-	"crypto/sha256"
 	"fmt"
-	"hash"
 
 	"github.com/google/uuid"
-	"github.com/lithammer/shortuuid"
+	"golang.org/x/text/language"
 )
 
 // HashAlgorithm captures the supported hasing algorithms
@@ -20,17 +15,51 @@ const (
 	// SHA1 is a hashing algorithm that produces a 256-bit digest.
 	SHA1 HashAlgorithm = "sha1"
 	// SHA256 is a hashing algorithm that produces a 256-bit digest.
-	SHA256      HashAlgorithm = "sha256"
+	SHA256 HashAlgorithm = "sha256"
+	// SHA512 is a hashing algorithm that produces a 512-bit digest.
+	SHA512 HashAlgorithm = "sha512"
+	// SHA3_256 is the SHA-3 hashing algorithm with a 256-bit digest.
+	SHA3_256 HashAlgorithm = "sha3-256"
+	// BLAKE2B is the BLAKE2b hashing algorithm with a 256-bit digest.
+	BLAKE2B HashAlgorithm = "blake2b"
+	// BLAKE3 is the BLAKE3 hashing algorithm with a 256-bit digest.
+	BLAKE3 HashAlgorithm = "blake3"
+
 	HMAC_SHA256 HashAlgorithm = "hmac"
+	// HMAC_SHA512 is HMAC using SHA512, requires a key.
+	HMAC_SHA512 HashAlgorithm = "hmac-sha512"
+	// HMAC_SHA3_256 is HMAC using SHA3_256, requires a key.
+	HMAC_SHA3_256 HashAlgorithm = "hmac-sha3-256"
+	// HMAC_BLAKE2B is HMAC using BLAKE2B, requires a key.
+	HMAC_BLAKE2B HashAlgorithm = "hmac-blake2b"
+	// HMAC_BLAKE3 is HMAC using BLAKE3, requires a key.
+	HMAC_BLAKE3 HashAlgorithm = "hmac-blake3"
 )
 
 type options struct {
-	hashAlgo    HashAlgorithm
-	normalize   bool
-	normalizer  func(string) (string, error)
-	uuidVersion int
-	hmacKey     []byte
-	charMap     map[string]string
+	hashAlgo     HashAlgorithm
+	hashAlgoSet  bool
+	normalize    bool
+	normalizer   func(string) (string, error)
+	pipeline     Pipeline
+	uuidVersion  int
+	hmacKey      []byte
+	charMap      map[string]string
+	namespace    *uuid.UUID
+	jsonPointers []string
+	fingerprint  bool
+
+	shortEncoding Encoding
+
+	caseFolding CaseFolding
+	locale      language.Tag
+
+	// normalizerErr is set by options that fail to build a normalizer,
+	// and cleared by options that replace the normalizer
+	normalizerErr error
+
+	strictVersion  bool
+	legacyVersions bool
 }
 
 // Option configures the behavior of the New function. It allows you to set
@@ -41,27 +70,32 @@ func defaultOptions() options {
 	return options{
 		hashAlgo:    MD5,
 		normalize:   true,
-		normalizer:  Normalizer,
-		uuidVersion: 3,
+		normalizer:  nil,
+		uuidVersion: 0,
 		hmacKey:     nil,
 		charMap:     nil,
+		namespace:   nil,
+
+		shortEncoding: Base57,
 	}
 }
 
-// WithHashAlgorithm sets the hashing algorithm for generating the UUID.
+// WithHashAlgorithm sets the hashing algorithm for generating the UUID,
+// and selects the UUID version that corresponds to it: 3 for MD5,
+// 5 for SHA1, 8 for everything else and the version given to
+// RegisterAlgorithm for custom algorithms. Use WithLegacyVersions
+// to keep version 3 for SHA256 as earlier releases did.
 //
-// Supported algorithms: MD5, SHA1, SHA256, HMAC-SHA256.
+// Supported algorithms: MD5, SHA1, SHA256, SHA512, SHA3-256, BLAKE2b,
+// BLAKE3, the HMAC variants HMAC-SHA256, HMAC-SHA512, HMAC-SHA3-256,
+// HMAC-BLAKE2b and HMAC-BLAKE3, and any registered algorithm.
 func WithHashAlgorithm(algo HashAlgorithm) Option {
 	return func(o *options) {
 		o.hashAlgo = algo
-		switch algo {
-		case SHA1:
-			o.uuidVersion = 5
-		case HMAC_SHA256:
-			o.uuidVersion = 8
-		default:
-			o.uuidVersion = 3
-		}
+		o.hashAlgoSet = true
+		// resolved by NewGenerator, so the result does not
+		// depend on the order of WithLegacyVersions
+		o.uuidVersion = 0
 	}
 }
 
@@ -73,16 +107,18 @@ func WithHashAlgorithm(algo HashAlgorithm) Option {
 // and other characters make no difference.
 func WithCustomNormalizer(normalizer func(string) (string, error)) Option {
 	return func(o *options) {
-		o.normalizer = normalizer
+		o.normalizer, o.normalizerErr = normalizer, nil
 	}
 }
 
 // WithHMACKey will set the HMAC key used to hash
-// the input strings.
+// the input strings. Without WithHashAlgorithm the
+// algorithm is HMAC_SHA256, NewGenerator returns an
+// error wrapping ErrInvalidKey when the algorithm set
+// with WithHashAlgorithm does not use a key.
 func WithHMACKey(key []byte) Option {
 	return func(o *options) {
 		o.hmacKey = key
-		o.hashAlgo = HMAC_SHA256
 	}
 }
 
@@ -94,7 +130,9 @@ func WithNormalization(normalize bool) Option {
 	}
 }
 
-// WithUUIDVersion allows explicitly setting the UUID version
+// WithUUIDVersion allows explicitly setting the UUID version.
+// A version that does not match the algorithm is reported by
+// Generator.Warnings, or rejected with WithStrictVersion.
 func WithUUIDVersion(version int) Option {
 	return func(o *options) {
 		o.uuidVersion = version
@@ -116,35 +154,43 @@ func WithCustomCharMap(mapping map[string]string) Option {
 	}
 }
 
+// NewUUID generates a uuid.UUID from the provided input string.
+// It supports the same options as New.
 func NewUUID(input string, opts ...Option) (uuid.UUID, error) {
-	id, err := New(input, opts...)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	uid, err := uuid.Parse(id)
+	gen, err := NewGenerator(opts...)
 	if err != nil {
 		return uuid.Nil, err
 	}
-
-	return uid, nil
+	return gen.NewUUID(input)
 }
 
+// NewShortID generates a short ID from the provided input
+// string, base57 encoded unless set with WithShortEncoding.
+// It supports the same options as New.
 func NewShortID(input string, opts ...Option) (string, error) {
-	uid, err := NewUUID(input, opts...)
+	gen, err := NewGenerator(opts...)
 	if err != nil {
 		return "", err
 	}
-	enc := shortuuid.DefaultEncoder.Encode(uid)
-	return enc, nil
+	return gen.NewShortID(input)
 }
 
-func ParseShortID(sid string) (uuid.UUID, error) {
-	uid, err := shortuuid.DefaultEncoder.Decode(sid)
-	if err != nil {
-		return uuid.Nil, err
+// ParseShortID decodes a short ID generated by NewShortID
+// back into a uuid.UUID. Use WithShortEncoding when the ID
+// was not generated with the default Base57 encoding, other
+// options are ignored. Base57 IDs have 13 to 22 characters, as
+// shortuuid does not pad them further. Errors are of type
+// *ParseError and wrap ErrInvalidShortID.
+func ParseShortID(sid string, opts ...Option) (uuid.UUID, error) {
+	config := defaultOptions()
+	for _, opt := range opts {
+		opt(&config)
 	}
-	return uid, nil
+
+	if config.shortEncoding == nil {
+		return uuid.Nil, encodingError(fmt.Errorf("%w: encoding is nil", ErrUnknownEncoding))
+	}
+	return config.shortEncoding.Decode(sid)
 }
 
 // New generates a UUID from the provided input string,
@@ -152,6 +198,9 @@ func ParseShortID(sid string) (uuid.UUID, error) {
 // the same so will the ouptut.
 // The algorithm used is defined by the options passed.
 //
+// If you need to generate many IDs with the same options
+// use a Generator instead.
+//
 // Example:
 //
 //	id, err := hashid.New("user@example.com",
@@ -161,71 +210,11 @@ func ParseShortID(sid string) (uuid.UUID, error) {
 //	}
 //	fmt.Println(id)
 func New(input string, opts ...Option) (string, error) {
-	config := defaultOptions()
-
-	for _, opt := range opts {
-		opt(&config)
-	}
-
-	if config.hashAlgo == HMAC_SHA256 && config.hmacKey == nil {
-		return "", fmt.Errorf("HMAC key is required when using HMAC_SHA256")
-	}
-
-	uuidVersion := 0
-	switch config.uuidVersion {
-	case 3, 5, 8:
-		uuidVersion = config.uuidVersion
-	case 0:
-		uuidVersion = 3
-	default:
-		return "", fmt.Errorf("UUID version should be one of 3, 5, 8")
-	}
-
-	var normalizer func(string) (string, error)
-	if config.charMap != nil {
-		n, err := newNormalizer(config.charMap, "-")
-		if err != nil {
-			return "", err
-		}
-		normalizer = n.normalize
-	} else {
-		normalizer = config.normalizer
-	}
-
-	var err error
-
-	if config.normalize {
-		input, err = normalizer(input)
-	}
-
-	if err != nil {
-		return "", fmt.Errorf("normalization error: %w", err)
-	}
-
-	hasher, err := getHasher(config.hashAlgo, config.hmacKey)
+	gen, err := NewGenerator(opts...)
 	if err != nil {
 		return "", err
 	}
-	hasher.Write([]byte(input))
-	hash := hasher.Sum(nil)
-
-	return formatUUID(hash, uuidVersion), nil
-}
-
-func getHasher(algo HashAlgorithm, key []byte) (hash.Hash, error) {
-	switch algo {
-	case SHA1:
-		return sha1.New(), nil
-	case SHA256:
-		return sha256.New(), nil
-	case HMAC_SHA256:
-		if key == nil {
-			return nil, fmt.Errorf("HMAC key is required when using HMAC_SHA256")
-		}
-		return hmac.New(sha256.New, key), nil
-	default:
-		return md5.New(), nil
-	}
+	return gen.New(input)
 }
 
 // Valid UUID version 3, following the format
@@ -233,26 +222,21 @@ func getHasher(algo HashAlgorithm, key []byte) (hash.Hash, error) {
 // Where:
 // - The 13th character is always 3 (indicating a version 3 UUID).
 // - The 17th character is one of 8, 9, a, or b.
-func formatUUID(hash []byte, version int) string {
-	var versionByte uint8
+func formatUUID(hash []byte, version int) uuid.UUID {
+	var uid uuid.UUID
+	copy(uid[:], hash[:16])
+
 	if version == 8 {
 		// For version 8, we set the version but don't modify any other bits
 		// as per RFC 9562, allowing for custom formats
-		versionByte = hash[6]&0x0F | 0x80
+		uid[6] = hash[6]&0x0F | 0x80
 	} else {
 		// For other versions (3, 5), use traditional version formatting
-		versionByte = hash[6]&0x0F | uint8(version<<4)
+		uid[6] = hash[6]&0x0F | uint8(version<<4)
 	}
 
 	// Set the variant to RFC 4122 (the 2 most significant bits should be 10)
-	variantByte := hash[8]&0x3F | 0x80
+	uid[8] = hash[8]&0x3F | 0x80
 
-	// Return the UUID formatted string
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		hash[0:4],
-		hash[4:6],
-		[]byte{versionByte, hash[7]},
-		[]byte{variantByte, hash[9]},
-		hash[10:16],
-	)
+	return uid
 }
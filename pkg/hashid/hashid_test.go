@@ -2,10 +2,12 @@ package hashid
 
 import (
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -264,7 +266,9 @@ func TestNewUUID(t *testing.T) {
 			version := uid.Version()
 			if len(tc.options) > 0 {
 				opts := defaultOptions()
-				tc.options[0](&opts)
+				for _, opt := range tc.options {
+					opt(&opts)
+				}
 				switch opts.hashAlgo {
 				case SHA1:
 					assert.Equal(t, uuid.Version(5), version)
@@ -343,6 +347,21 @@ func TestParseShortUUID(t *testing.T) {
 			input:   "2M9JFnemic9bLiXnT8AHun",
 			wantErr: false,
 		},
+		{
+			name:    "Short ID without padding",
+			input:   "2M9JFnemic9bLiXnT8AHu",
+			wantErr: false,
+		},
+		{
+			name:    "Shortest short ID",
+			input:   "2M9JFnemic9bL",
+			wantErr: false,
+		},
+		{
+			name:    "Too short",
+			input:   "2M9JFnemic9b",
+			wantErr: true,
+		},
 		{
 			name:    "Invalid characters",
 			input:   "invalid-uuid-format!!!",
@@ -364,6 +383,25 @@ func TestParseShortUUID(t *testing.T) {
 	}
 }
 
+func TestParseShortIDLengths(t *testing.T) {
+	// earlier releases only padded short IDs to 13 characters
+	for length := 13; length <= 22; length++ {
+		sid := strings.Repeat("3", length)
+		uid, err := ParseShortID(sid)
+		assert.NoError(t, err, sid)
+		assert.Equal(t, sid, shortuuid.DefaultEncoder.Encode(uid))
+
+		parsed, err := ParseShortID(Base57Padded.Encode(uid))
+		assert.NoError(t, err, sid)
+		assert.Equal(t, uid, parsed)
+	}
+
+	for _, sid := range []string{"333333333333", strings.Repeat("3", 23), strings.Repeat("z", 22)} {
+		_, err := ParseShortID(sid)
+		assert.ErrorIs(t, err, ErrInvalidShortID, sid)
+	}
+}
+
 func TestUUIDRoundTrip(t *testing.T) {
 	testInputs := []string{
 		"test@example.com",
@@ -0,0 +1,103 @@
+package hashid
+
+import (
+	"bytes"
+
+	"github.com/google/uuid"
+)
+
+// fingerprintTagSize is the number of fingerprint bytes
+// embedded in the last bytes of version 8 UUIDs
+const fingerprintTagSize = 2
+
+// WithEmbeddedFingerprint replaces the last 16 bits of generated
+// UUIDs with a tag derived from the generator Fingerprint, so
+// that Inspect can tell which profile produced an ID. It requires
+// UUID version 8, where those bits are free for custom use.
+//
+// Embedding the tag changes the generated IDs and reduces the
+// number of hashed bits from 122 to 106.
+func WithEmbeddedFingerprint() Option {
+	return func(o *options) {
+		o.fingerprint = true
+	}
+}
+
+// Inspection describes a UUID as seen by hashid.
+type Inspection struct {
+	// UUID is the inspected UUID.
+	UUID uuid.UUID
+	// Version is the UUID version.
+	Version int
+	// Variant is the UUID variant.
+	Variant uuid.Variant
+	// Conforms reports whether the version and variant bits
+	// are the ones hashid sets: RFC 4122 variant with
+	// version 3, 5 or 8.
+	Conforms bool
+	// Algorithms lists the algorithms that generate this
+	// UUID version by default.
+	Algorithms []HashAlgorithm
+	// Profiles lists the fingerprints of the given generators
+	// whose embedded fingerprint matches the UUID.
+	Profiles []string
+}
+
+// Inspect parses id, a UUID string or a short ID, and reports
+// its version, variant, and the algorithms that could have
+// produced it. Generators created with WithEmbeddedFingerprint
+// can be passed as profiles to find out which one produced
+// the ID.
+//
+// A matching profile is a strong hint, not a proof: a random
+// UUID matches a given profile once every 65536 IDs. Use Verify
+// when the input is known.
+//
+// Example:
+//
+//	info, err := hashid.Inspect(id, billing, accounts)
+//	fmt.Println(info.Version, info.Algorithms, info.Profiles)
+func Inspect(id string, profiles ...*Generator) (Inspection, error) {
+	uid, err := ParseID(id)
+	if err != nil {
+		return Inspection{}, err
+	}
+	return InspectUUID(uid, profiles...), nil
+}
+
+// InspectUUID is like Inspect for a uuid.UUID.
+func InspectUUID(uid uuid.UUID, profiles ...*Generator) Inspection {
+	info := Inspection{
+		UUID:       uid,
+		Version:    int(uid.Version()),
+		Variant:    uid.Variant(),
+		Algorithms: []HashAlgorithm{},
+		Profiles:   []string{},
+	}
+
+	switch info.Version {
+	case 3, 5, 8:
+		info.Conforms = info.Variant == uuid.RFC4122
+	}
+
+	if !info.Conforms {
+		return info
+	}
+
+	for _, algo := range Algorithms() {
+		if algo.DefaultVersion() == info.Version {
+			info.Algorithms = append(info.Algorithms, algo)
+		}
+	}
+
+	for _, gen := range profiles {
+		if gen.tag == nil || gen.version != info.Version {
+			continue
+		}
+		if bytes.Equal(uid[16-fingerprintTagSize:], gen.tag) {
+			info.Profiles = append(info.Profiles, gen.Fingerprint())
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,120 @@
+package hashid
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       []Option
+		version    int
+		algorithms []HashAlgorithm
+	}{
+		{"md5", nil, 3, []HashAlgorithm{MD5}},
+		{"sha1", []Option{WithHashAlgorithm(SHA1)}, 5, []HashAlgorithm{SHA1}},
+		{"sha256", []Option{WithHashAlgorithm(SHA256)}, 8, []HashAlgorithm{SHA256, BLAKE3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := New("user@example.com", tc.opts...)
+			require.NoError(t, err)
+
+			info, err := Inspect(id)
+			require.NoError(t, err)
+
+			assert.Equal(t, id, info.UUID.String())
+			assert.Equal(t, tc.version, info.Version)
+			assert.Equal(t, uuid.RFC4122, info.Variant)
+			assert.True(t, info.Conforms)
+			assert.Empty(t, info.Profiles)
+
+			for _, algo := range tc.algorithms {
+				assert.Contains(t, info.Algorithms, algo)
+			}
+			for _, algo := range info.Algorithms {
+				assert.Equal(t, tc.version, algo.DefaultVersion())
+			}
+		})
+	}
+}
+
+func TestInspectShortID(t *testing.T) {
+	sid, err := NewShortID("user@example.com")
+	require.NoError(t, err)
+
+	info, err := Inspect(sid)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.Version)
+}
+
+func TestInspectNonConforming(t *testing.T) {
+	// version 4, random UUID
+	info, err := Inspect("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	require.NoError(t, err)
+	assert.Equal(t, 4, info.Version)
+	assert.False(t, info.Conforms)
+	assert.Empty(t, info.Algorithms)
+
+	// version 3 with the Microsoft variant
+	info, err = Inspect("ddea575a-d5e2-3114-c267-dbead79c4ab8")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Microsoft, info.Variant)
+	assert.False(t, info.Conforms)
+
+	_, err = Inspect("not-a-uuid")
+	assert.Error(t, err)
+}
+
+func TestEmbeddedFingerprint(t *testing.T) {
+	billing, err := NewGenerator(
+		WithHashAlgorithm(HMAC_SHA256),
+		WithHMACKey([]byte("billing")),
+		WithEmbeddedFingerprint())
+	require.NoError(t, err)
+
+	accounts, err := NewGenerator(
+		WithHashAlgorithm(BLAKE3),
+		WithEmbeddedFingerprint())
+	require.NoError(t, err)
+
+	plain, err := NewGenerator(WithHashAlgorithm(HMAC_SHA256), WithHMACKey([]byte("billing")))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, billing.Fingerprint(), plain.Fingerprint())
+
+	for _, input := range []string{"user@example.com", "other@example.com", "x"} {
+		uid, err := billing.NewUUID(input)
+		require.NoError(t, err)
+
+		info := InspectUUID(uid, accounts, billing, plain)
+		assert.Equal(t, 8, info.Version)
+		assert.True(t, info.Conforms)
+		assert.Equal(t, []string{billing.Fingerprint()}, info.Profiles)
+
+		// only the tag bits differ from the plain generator
+		expected, err := plain.NewUUID(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected[:14], uid[:14])
+	}
+
+	uid, err := accounts.NewUUID("user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{accounts.Fingerprint()}, InspectUUID(uid, billing, accounts).Profiles)
+}
+
+func TestEmbeddedFingerprintRequiresVersion8(t *testing.T) {
+	_, err := NewGenerator(WithEmbeddedFingerprint())
+	assert.Error(t, err)
+
+	_, err = NewGenerator(WithHashAlgorithm(SHA1), WithEmbeddedFingerprint())
+	assert.Error(t, err)
+
+	_, err = NewGenerator(WithEmbeddedFingerprint(), WithUUIDVersion(8))
+	assert.NoError(t, err)
+}
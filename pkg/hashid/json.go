@@ -0,0 +1,358 @@
+package hashid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// WithJSONPointers selects the fields used by FromJSON to
+// generate the ID. Each pointer follows RFC 6901, e.g.
+// "/customer/email" or "/items/0/sku". The selected values
+// are combined in an object keyed by pointer, so only the
+// identity bearing fields of a document affect the ID.
+func WithJSONPointers(pointers ...string) Option {
+	return func(o *options) {
+		o.jsonPointers = pointers
+	}
+}
+
+// FromJSON generates a UUID string from a JSON document.
+//
+// The document is canonicalized following RFC 8785 (JCS):
+// object keys are sorted, insignificant whitespace removed,
+// numbers serialized in their shortest form and strings
+// normalized to NFC. Documents that differ only in key order
+// or formatting produce the same ID.
+//
+// The configured normalizer is not applied since it would
+// alter the document structure.
+//
+// Example:
+//
+//	id, err := hashid.FromJSON(payload,
+//		hashid.WithJSONPointers("/partner", "/order/id"))
+func FromJSON(data []byte, opts ...Option) (string, error) {
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		return "", err
+	}
+	return gen.FromJSON(data)
+}
+
+// FromJSONUUID generates a uuid.UUID from a JSON document.
+// See FromJSON.
+func FromJSONUUID(data []byte, opts ...Option) (uuid.UUID, error) {
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gen.FromJSONUUID(data)
+}
+
+// FromJSON generates a UUID string from a JSON document.
+// See FromJSON.
+func (g *Generator) FromJSON(data []byte) (string, error) {
+	uid, err := g.FromJSONUUID(data)
+	if err != nil {
+		return "", err
+	}
+	return uid.String(), nil
+}
+
+// FromJSONUUID generates a uuid.UUID from a JSON document.
+// See FromJSON.
+func (g *Generator) FromJSONUUID(data []byte) (uuid.UUID, error) {
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(g.config.jsonPointers) > 0 {
+		selected := make(map[string]any, len(g.config.jsonPointers))
+		for _, pointer := range g.config.jsonPointers {
+			value, err := resolveJSONPointer(doc, pointer)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			selected[pointer] = value
+		}
+		doc = selected
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, doc); err != nil {
+		return uuid.Nil, err
+	}
+
+	return g.hash(buf.Bytes())
+}
+
+// CanonicalJSON returns the canonical representation of a
+// JSON document as used by FromJSON.
+func CanonicalJSON(data []byte) ([]byte, error) {
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	doc, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+
+	return doc, nil
+}
+
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := map[string]any{}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := unicodeNorm(keyTok.(string))
+				if _, ok := obj[key]; ok {
+					return nil, fmt.Errorf("duplicated key %q", key)
+				}
+				value, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj[key] = value
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return obj, nil
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				value, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, value)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return arr, nil
+		}
+	case string:
+		return unicodeNorm(t), nil
+	case json.Number:
+		f, err := strconv.ParseFloat(t.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %w", t, err)
+		}
+		return f, nil
+	case bool, nil:
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+func resolveJSONPointer(doc any, pointer string) (any, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	current := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[unicodeNorm(token)]
+			if !ok {
+				return nil, fmt.Errorf("JSON pointer %q not found", pointer)
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) || (len(token) > 1 && token[0] == '0') {
+				return nil, fmt.Errorf("JSON pointer %q not found", pointer)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("JSON pointer %q not found", pointer)
+		}
+	}
+
+	return current, nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case float64:
+		n, err := formatJSONNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case string:
+		writeJSONString(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		// RFC 8785 sorts keys by their UTF-16 code units
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported JSON value %T", value)
+	}
+	return nil
+}
+
+// formatJSONNumber serializes numbers as ECMAScript does,
+// which is what RFC 8785 requires.
+func formatJSONNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("invalid JSON number")
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+
+	s := strconv.FormatFloat(f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(s)
+		if n >= 4 && s[n-4] == 'e' && s[n-3] == '-' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+	return s, nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xF])
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+func lessUTF16(a, b string) bool {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			return compareUTF16(ra, rb)
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return len(a) < len(b)
+}
+
+func compareUTF16(a, b rune) bool {
+	ua, ub := utf16.Encode([]rune{a}), utf16.Encode([]rune{b})
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
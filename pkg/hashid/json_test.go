@@ -0,0 +1,107 @@
+package hashid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"RFC 8785 example": {
+			input:    `{"numbers":[333333333.33333329,1E30,4.50,2e-3,0.000000000000000000000000001],"string":"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/","literals":[null,true,false]}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		"nested objects and whitespace": {
+			input:    "{ \"b\" : { \"d\": 1, \"c\": [ 1 , 2 ] },\n \"a\": \"x\" }",
+			expected: `{"a":"x","b":{"c":[1,2],"d":1}}`,
+		},
+		"UTF-16 key ordering": {
+			input:    `{"ﬁ":1,"😀":2,"a":3}`,
+			expected: `{"a":3,"😀":2,"ﬁ":1}`,
+		},
+		"NFC strings": {
+			input:    `{"name":"Café"}`,
+			expected: `{"name":"Café"}`,
+		},
+		"negative zero": {
+			input:    `[-0, 0.0, 100, -1.5e2]`,
+			expected: `[0,0,100,-150]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			out, err := CanonicalJSON([]byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(out))
+		})
+	}
+}
+
+func TestCanonicalJSONErrors(t *testing.T) {
+	testCases := map[string]string{
+		"invalid JSON":    `{"a":`,
+		"trailing data":   `{"a":1} {"b":2}`,
+		"duplicated keys": `{"a":1,"a":2}`,
+		"number overflow": `1e400`,
+	}
+
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := CanonicalJSON([]byte(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	a, err := FromJSON([]byte(`{"partner":"acme","order":{"id":1234,"total":10.50}}`))
+	require.NoError(t, err)
+
+	b, err := FromJSON([]byte("{\n  \"order\": {\"total\": 10.5, \"id\": 1234.0},\n  \"partner\": \"acme\"\n}"))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := FromJSON([]byte(`{"partner":"ACME","order":{"id":1234,"total":10.50}}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c, "string values are not normalized")
+
+	expected, err := New(`{"order":{"id":1234,"total":10.5},"partner":"acme"}`, WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, expected, a)
+
+	uid, err := FromJSONUUID([]byte(`{"partner":"acme","order":{"id":1234,"total":10.50}}`))
+	require.NoError(t, err)
+	assert.Equal(t, a, uid.String())
+}
+
+func TestFromJSONWithPointers(t *testing.T) {
+	opts := []Option{WithJSONPointers("/partner", "/order/id")}
+
+	a, err := FromJSON([]byte(`{"partner":"acme","order":{"id":1234,"received":"2024-01-01"}}`), opts...)
+	require.NoError(t, err)
+
+	b, err := FromJSON([]byte(`{"partner":"acme","order":{"id":1234,"received":"2024-02-02"},"retry":3}`), opts...)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := FromJSON([]byte(`{"partner":"acme","order":{"id":4321}}`), opts...)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	gen, err := NewGenerator(WithJSONPointers("/items/1/sku", "/a~1b"))
+	require.NoError(t, err)
+	_, err = gen.FromJSON([]byte(`{"items":[{"sku":"x"},{"sku":"y"}],"a/b":true}`))
+	assert.NoError(t, err)
+
+	_, err = FromJSON([]byte(`{"partner":"acme"}`), opts...)
+	assert.Error(t, err, "missing pointer")
+
+	_, err = FromJSON([]byte(`{"partner":"acme"}`), WithJSONPointers("partner"))
+	assert.Error(t, err, "invalid pointer")
+}
@@ -0,0 +1,113 @@
+package hashid
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// derivedKeySize is the size of keys derived with DeriveKey
+const derivedKeySize = 32
+
+// WithHKDF sets the HMAC key to one derived from master
+// with DeriveKey. Use a different info per service so
+// each one gets its own key from a shared secret.
+//
+// Example:
+//
+//	id, _ := hashid.New("user@example.com",
+//		hashid.WithHKDF(master, []byte("billing-service")))
+func WithHKDF(master, info []byte) Option {
+	return WithHMACKey(DeriveKey(master, info))
+}
+
+// DeriveKey derives a 256-bit key from the master secret and
+// the context info using HKDF-SHA256 without a salt. The same
+// master and info always derive the same key.
+func DeriveKey(master, info []byte) []byte {
+	key := make([]byte, derivedKeySize)
+	// reading 32 bytes from HKDF-SHA256 never fails
+	io.ReadFull(hkdf.New(sha256.New, master, nil, info), key)
+	return key
+}
+
+// ParseKey decodes a key. Values prefixed with "hex:" or
+// "base64:" are decoded, anything else is used as is.
+//
+// Example:
+//
+//	key, err := hashid.ParseKey("hex:6d7973656372657421")
+func ParseKey(value string) ([]byte, error) {
+	var (
+		key []byte
+		err error
+	)
+
+	switch {
+	case strings.HasPrefix(value, "hex:"):
+		key, err = hex.DecodeString(value[len("hex:"):])
+	case strings.HasPrefix(value, "base64:"):
+		key, err = decodeBase64(value[len("base64:"):])
+	default:
+		key = []byte(value)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("key is empty")
+	}
+	return key, nil
+}
+
+// KeyFromEnv reads a key from the environment variable
+// name and decodes it with ParseKey.
+func KeyFromEnv(name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	key, err := ParseKey(value)
+	if err != nil {
+		return nil, fmt.Errorf("environment variable %s: %w", name, err)
+	}
+	return key, nil
+}
+
+// KeyFromFile reads a key from a file and decodes it with
+// ParseKey. A trailing newline is removed, so files created
+// with echo or an editor work as expected.
+func KeyFromFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	value := strings.TrimSuffix(string(data), "\n")
+	value = strings.TrimSuffix(value, "\r")
+
+	key, err := ParseKey(value)
+	if err != nil {
+		return nil, fmt.Errorf("key file %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// decodeBase64 accepts standard and URL safe encodings,
+// with or without padding
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(value, "=")
+	if strings.ContainsAny(value, "-_") {
+		return base64.RawURLEncoding.DecodeString(value)
+	}
+	return base64.RawStdEncoding.DecodeString(value)
+}
@@ -0,0 +1,100 @@
+package hashid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveKey(t *testing.T) {
+	// RFC 5869 test case 3, first 32 bytes of the OKM
+	master := bytes.Repeat([]byte{0x0b}, 22)
+	expected := "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d"
+	assert.Equal(t, expected, hex.EncodeToString(DeriveKey(master, nil)))
+
+	billing := DeriveKey([]byte("master"), []byte("billing"))
+	assert.Equal(t, billing, DeriveKey([]byte("master"), []byte("billing")))
+	assert.NotEqual(t, billing, DeriveKey([]byte("master"), []byte("accounts")))
+}
+
+func TestWithHKDF(t *testing.T) {
+	master := []byte("master")
+
+	id, err := New("user@example.com", WithHKDF(master, []byte("billing")))
+	require.NoError(t, err)
+
+	expected, err := New("user@example.com", WithHMACKey(DeriveKey(master, []byte("billing"))))
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	other, err := New("user@example.com", WithHKDF(master, []byte("accounts")))
+	require.NoError(t, err)
+	assert.NotEqual(t, id, other)
+}
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+		err      bool
+	}{
+		{"raw", "mysecret", "mysecret", false},
+		{"hex", "hex:6d79736563726574", "mysecret", false},
+		{"base64", "base64:bXlzZWNyZXQ=", "mysecret", false},
+		{"base64 without padding", "base64:bXlzZWNyZXQ", "mysecret", false},
+		{"base64 url safe", "base64:-_8", "\xfb\xff", false},
+		{"invalid hex", "hex:zz", "", true},
+		{"invalid base64", "base64:!!", "", true},
+		{"empty", "", "", true},
+		{"empty hex", "hex:", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseKey(tc.value)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte(tc.expected), key)
+		})
+	}
+}
+
+func TestKeyFromEnv(t *testing.T) {
+	t.Setenv("HASHID_TEST_KEY", "hex:6d79736563726574")
+
+	key, err := KeyFromEnv("HASHID_TEST_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mysecret"), key)
+
+	_, err = KeyFromEnv("HASHID_TEST_KEY_NOT_SET")
+	assert.Error(t, err)
+}
+
+func TestKeyFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(path, []byte("base64:bXlzZWNyZXQ=\r\n"), 0o600))
+
+	key, err := KeyFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mysecret"), key)
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+
+	_, err = KeyFromFile(empty)
+	assert.Error(t, err)
+
+	_, err = KeyFromFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
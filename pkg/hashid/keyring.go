@@ -0,0 +1,257 @@
+package hashid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Keyring holds versioned HMAC keys so that keys can be rotated
+// without losing the ability to recognize IDs generated with
+// previous keys. New IDs are always generated with the primary
+// key, while Match and Verify try every active key.
+//
+// A Keyring is safe for concurrent use.
+//
+// Example:
+//
+//	kr, _ := hashid.NewKeyring(hashid.WithHashAlgorithm(hashid.HMAC_SHA512))
+//	kr.AddKey("2023", oldKey)
+//	kr.AddKey("2024", newKey)
+//	kr.SetPrimary("2024")
+//
+//	id, _ := kr.New("user@example.com")
+//	version, _ := kr.Match("user@example.com", storedID)
+type Keyring struct {
+	mu      sync.RWMutex
+	opts    []Option
+	keys    []*keyringEntry
+	primary *keyringEntry
+}
+
+type keyringEntry struct {
+	version string
+	active  bool
+	gen     *Generator
+}
+
+// NewKeyring creates an empty Keyring. The options are used for
+// every key, the algorithm defaults to HMAC_SHA256.
+func NewKeyring(opts ...Option) (*Keyring, error) {
+	// copy to prevent the caller from modifying our options
+	opts = append([]Option{WithHashAlgorithm(HMAC_SHA256)}, opts...)
+
+	// validate the options with a placeholder key
+	if _, err := NewGenerator(append(opts, WithHMACKey([]byte("keyring")))...); err != nil {
+		return nil, err
+	}
+
+	return &Keyring{opts: opts}, nil
+}
+
+// AddKey adds an active key identified by version. The first
+// key added becomes the primary key.
+func (k *Keyring) AddKey(version string, key []byte) error {
+	if version == "" {
+		return fmt.Errorf("key version is required")
+	}
+
+	if len(key) == 0 {
+		return fmt.Errorf("key %s is empty", version)
+	}
+
+	// copy to prevent the caller from modifying the key
+	key = append([]byte(nil), key...)
+
+	gen, err := NewGenerator(append(k.opts, WithHMACKey(key))...)
+	if err != nil {
+		return fmt.Errorf("key %s: %w", version, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.lookup(version) != nil {
+		return fmt.Errorf("key %s already exists", version)
+	}
+
+	entry := &keyringEntry{version: version, active: true, gen: gen}
+	k.keys = append(k.keys, entry)
+
+	if k.primary == nil {
+		k.primary = entry
+	}
+	return nil
+}
+
+// SetPrimary sets the key used to generate new IDs.
+// The key must be active.
+func (k *Keyring) SetPrimary(version string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	entry := k.lookup(version)
+	if entry == nil {
+		return fmt.Errorf("key %s not found", version)
+	}
+
+	if !entry.active {
+		return fmt.Errorf("key %s is retired", version)
+	}
+
+	k.primary = entry
+	return nil
+}
+
+// Retire deactivates a key, IDs generated with it will no
+// longer match. The primary key can not be retired.
+func (k *Keyring) Retire(version string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	entry := k.lookup(version)
+	if entry == nil {
+		return fmt.Errorf("key %s not found", version)
+	}
+
+	if entry == k.primary {
+		return fmt.Errorf("key %s is the primary key", version)
+	}
+
+	entry.active = false
+	return nil
+}
+
+// Primary returns the version of the primary key.
+func (k *Keyring) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.primary == nil {
+		return ""
+	}
+	return k.primary.version
+}
+
+// Versions returns the versions of all active keys,
+// starting with the primary key.
+func (k *Keyring) Versions() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	versions := []string{}
+	for _, entry := range k.candidates() {
+		versions = append(versions, entry.version)
+	}
+	return versions
+}
+
+// New generates a UUID string using the primary key.
+func (k *Keyring) New(input string) (string, error) {
+	gen, err := k.primaryGenerator()
+	if err != nil {
+		return "", err
+	}
+	return gen.New(input)
+}
+
+// NewUUID generates a uuid.UUID using the primary key.
+func (k *Keyring) NewUUID(input string) (uuid.UUID, error) {
+	gen, err := k.primaryGenerator()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gen.NewUUID(input)
+}
+
+// NewShortID generates a short ID using the primary key.
+func (k *Keyring) NewShortID(input string) (string, error) {
+	gen, err := k.primaryGenerator()
+	if err != nil {
+		return "", err
+	}
+	return gen.NewShortID(input)
+}
+
+// Match returns the version of the active key that generated
+// id, a UUID string or a short ID, from input. The primary key
+// is tried first. It returns ErrNoMatchingKey if no active key
+// produced the ID.
+func (k *Keyring) Match(input, id string) (string, error) {
+	expected, err := ParseID(id, k.opts...)
+	if err != nil {
+		return "", err
+	}
+
+	k.mu.RLock()
+	candidates := k.candidates()
+	k.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("keyring has no keys")
+	}
+
+	for _, entry := range candidates {
+		uid, err := entry.gen.NewUUID(input)
+		if err != nil {
+			return "", err
+		}
+
+		if equalUUID(uid, expected) {
+			return entry.version, nil
+		}
+	}
+
+	return "", ErrNoMatchingKey
+}
+
+// Verify reports whether id was generated from input with
+// any of the active keys.
+func (k *Keyring) Verify(input, id string) (bool, error) {
+	_, err := k.Match(input, id)
+	if errors.Is(err, ErrNoMatchingKey) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (k *Keyring) primaryGenerator() (*Generator, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.primary == nil {
+		return nil, fmt.Errorf("keyring has no primary key")
+	}
+	return k.primary.gen, nil
+}
+
+// candidates returns the active keys, primary first.
+// Callers must hold the lock.
+func (k *Keyring) candidates() []*keyringEntry {
+	entries := make([]*keyringEntry, 0, len(k.keys))
+	if k.primary != nil {
+		entries = append(entries, k.primary)
+	}
+
+	for _, entry := range k.keys {
+		if entry.active && entry != k.primary {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// lookup returns the entry for version, callers must hold the lock
+func (k *Keyring) lookup(version string) *keyringEntry {
+	for _, entry := range k.keys {
+		if entry.version == version {
+			return entry
+		}
+	}
+	return nil
+}
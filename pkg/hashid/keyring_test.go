@@ -0,0 +1,136 @@
+package hashid
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	kr, err := NewKeyring()
+	require.NoError(t, err)
+
+	require.NoError(t, kr.AddKey("2023", []byte("old-secret")))
+	assert.Equal(t, "2023", kr.Primary())
+
+	oldID, err := kr.New("user@example.com")
+	require.NoError(t, err)
+
+	expected, err := New("user@example.com", WithHashAlgorithm(HMAC_SHA256), WithHMACKey([]byte("old-secret")))
+	require.NoError(t, err)
+	assert.Equal(t, expected, oldID)
+
+	require.NoError(t, kr.AddKey("2024", []byte("new-secret")))
+	require.NoError(t, kr.SetPrimary("2024"))
+	assert.Equal(t, []string{"2024", "2023"}, kr.Versions())
+
+	newID, err := kr.New("user@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, oldID, newID)
+
+	version, err := kr.Match("user@example.com", oldID)
+	require.NoError(t, err)
+	assert.Equal(t, "2023", version)
+
+	version, err = kr.Match("user@example.com", newID)
+	require.NoError(t, err)
+	assert.Equal(t, "2024", version)
+
+	ok, err := kr.Verify("user@example.com", oldID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = kr.Verify("other@example.com", oldID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, kr.Retire("2023"))
+	assert.Equal(t, []string{"2024"}, kr.Versions())
+
+	_, err = kr.Match("user@example.com", oldID)
+	assert.ErrorIs(t, err, ErrNoMatchingKey)
+
+	ok, err = kr.Verify("user@example.com", oldID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestKeyringOptions(t *testing.T) {
+	kr, err := NewKeyring(WithHashAlgorithm(HMAC_SHA512))
+	require.NoError(t, err)
+	require.NoError(t, kr.AddKey("v1", []byte("secret")))
+
+	uid, err := kr.NewUUID("user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(8), uid.Version())
+
+	expected, err := NewUUID("user@example.com", WithHashAlgorithm(HMAC_SHA512), WithHMACKey([]byte("secret")))
+	require.NoError(t, err)
+	assert.Equal(t, expected, uid)
+
+	sid, err := kr.NewShortID("user@example.com")
+	require.NoError(t, err)
+	parsed, err := ParseShortID(sid)
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	_, err = NewKeyring(WithUUIDVersion(10))
+	assert.Error(t, err)
+}
+
+func TestKeyringErrors(t *testing.T) {
+	kr, err := NewKeyring()
+	require.NoError(t, err)
+
+	_, err = kr.New("test")
+	assert.Error(t, err, "no primary key")
+
+	_, err = kr.Match("test", "ddea575a-d5e2-3114-9267-dbead79c4ab8")
+	assert.Error(t, err, "no keys")
+
+	assert.Error(t, kr.AddKey("", []byte("secret")))
+	assert.Error(t, kr.AddKey("v1", nil))
+
+	require.NoError(t, kr.AddKey("v1", []byte("secret")))
+	assert.Error(t, kr.AddKey("v1", []byte("other")), "duplicated version")
+
+	assert.Error(t, kr.SetPrimary("missing"))
+	assert.Error(t, kr.Retire("missing"))
+	assert.Error(t, kr.Retire("v1"), "primary key")
+
+	require.NoError(t, kr.AddKey("v2", []byte("other")))
+	require.NoError(t, kr.Retire("v2"))
+	assert.Error(t, kr.SetPrimary("v2"), "retired key")
+
+	_, err = kr.Match("test", "not-a-uuid")
+	assert.Error(t, err)
+}
+
+func TestKeyringConcurrentAccess(t *testing.T) {
+	kr, err := NewKeyring()
+	require.NoError(t, err)
+	require.NoError(t, kr.AddKey("v1", []byte("secret")))
+
+	id, err := kr.New("test")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			version, err := kr.Match("test", id)
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", version)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, kr.AddKey(fmt.Sprintf("k%d", i), []byte("k")))
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,127 @@
+package hashid
+
+import "github.com/google/uuid"
+
+// Predefined namespaces as defined in RFC 9562 Appendix A.
+var (
+	NamespaceDNS  = uuid.NameSpaceDNS
+	NamespaceURL  = uuid.NameSpaceURL
+	NamespaceOID  = uuid.NameSpaceOID
+	NamespaceX500 = uuid.NameSpaceX500
+)
+
+// WithNamespace sets the namespace used to derive the UUID.
+// The namespace bytes are hashed before the (normalized) input,
+// which for MD5 (v3) and SHA1 (v5) produces the same output as
+// the name-based UUIDs described in RFC 9562, e.g. the output of
+// uuid.NewMD5(ns, name) or uuid.NewSHA1(ns, name). The output is
+// only equal for input that is not normalized, the default
+// normalizer e.g. strips ".", use WithNormalization(false).
+//
+// When no namespace is given the legacy derivation is used,
+// which hashes only the input. This keeps previously issued
+// IDs reproducible.
+//
+// Example usage:
+//
+//	id, _ := hashid.New("example.com",
+//		hashid.WithHashAlgorithm(hashid.SHA1),
+//		hashid.WithNamespace(hashid.NamespaceDNS),
+//		hashid.WithNormalization(false))
+//	// same as uuid.NewSHA1(uuid.NameSpaceDNS, []byte("example.com"))
+func WithNamespace(ns uuid.UUID) Option {
+	return func(o *options) {
+		o.namespace = &ns
+	}
+}
+
+// WithLegacyDerivation removes any namespace previously set and
+// hashes only the input, this is the default behavior.
+func WithLegacyDerivation() Option {
+	return func(o *options) {
+		o.namespace = nil
+	}
+}
+
+// Namespace is an ID space derived from a parent namespace.
+// IDs generated within a namespace never collide with IDs
+// generated for the same input within a different namespace,
+// and the derivation chain is reproducible as long as the
+// same names and options are used.
+//
+// A Namespace is safe for concurrent use.
+//
+// Example usage:
+//
+//	tenant, _ := hashid.NewNamespace("acme", hashid.WithHashAlgorithm(hashid.SHA1))
+//	orders, _ := tenant.Child("orders")
+//	id, _ := orders.New("order-1234")
+type Namespace struct {
+	id   uuid.UUID
+	opts []Option
+	gen  *Generator
+}
+
+// NewNamespace derives a root Namespace from the given name.
+// The root namespace is derived within the nil UUID namespace
+// using the provided options, which are also used to derive
+// children and to generate IDs within the namespace.
+func NewNamespace(name string, opts ...Option) (*Namespace, error) {
+	root, err := NamespaceFromUUID(uuid.Nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return root.Child(name)
+}
+
+// NamespaceFromUUID creates a Namespace from an existing UUID,
+// e.g. one of the predefined namespaces or a stored tenant ID.
+func NamespaceFromUUID(id uuid.UUID, opts ...Option) (*Namespace, error) {
+	// copy to prevent the caller from modifying our options
+	opts = append([]Option(nil), opts...)
+
+	gen, err := NewGenerator(append(opts, WithNamespace(id))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Namespace{
+		id:   id,
+		opts: opts,
+		gen:  gen,
+	}, nil
+}
+
+// Child derives a new Namespace from name within this namespace.
+func (ns *Namespace) Child(name string) (*Namespace, error) {
+	id, err := ns.gen.NewUUID(name)
+	if err != nil {
+		return nil, err
+	}
+	return NamespaceFromUUID(id, ns.opts...)
+}
+
+// UUID returns the namespace UUID.
+func (ns *Namespace) UUID() uuid.UUID {
+	return ns.id
+}
+
+// String returns the namespace UUID as a string.
+func (ns *Namespace) String() string {
+	return ns.id.String()
+}
+
+// New generates a UUID string from the input within this namespace.
+func (ns *Namespace) New(input string) (string, error) {
+	return ns.gen.New(input)
+}
+
+// NewUUID generates a uuid.UUID from the input within this namespace.
+func (ns *Namespace) NewUUID(input string) (uuid.UUID, error) {
+	return ns.gen.NewUUID(input)
+}
+
+// NewShortID generates a short ID from the input within this namespace.
+func (ns *Namespace) NewShortID(input string) (string, error) {
+	return ns.gen.NewShortID(input)
+}
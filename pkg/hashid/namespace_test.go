@@ -0,0 +1,150 @@
+package hashid
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithNamespaceMatchesRFC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		options  []Option
+		expected uuid.UUID
+	}{
+		{
+			name:     "MD5 DNS namespace",
+			input:    "www.example.com",
+			options:  []Option{WithNamespace(NamespaceDNS)},
+			expected: uuid.NewMD5(NamespaceDNS, []byte("www.example.com")),
+		},
+		{
+			name:     "SHA1 DNS namespace",
+			input:    "www.example.com",
+			options:  []Option{WithHashAlgorithm(SHA1), WithNamespace(NamespaceDNS)},
+			expected: uuid.MustParse("2ed6657d-e927-568b-95e1-2665a8aea6a2"),
+		},
+		{
+			name:     "SHA1 URL namespace",
+			input:    "https://example.com/path",
+			options:  []Option{WithHashAlgorithm(SHA1), WithNamespace(NamespaceURL)},
+			expected: uuid.NewSHA1(NamespaceURL, []byte("https://example.com/path")),
+		},
+		{
+			name:     "MD5 OID namespace",
+			input:    "1.3.6.1",
+			options:  []Option{WithNamespace(NamespaceOID)},
+			expected: uuid.NewMD5(NamespaceOID, []byte("1.3.6.1")),
+		},
+		{
+			name:     "SHA1 X500 namespace",
+			input:    "cn=John Doe",
+			options:  []Option{WithHashAlgorithm(SHA1), WithNamespace(NamespaceX500)},
+			expected: uuid.NewSHA1(NamespaceX500, []byte("cn=John Doe")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(tc.options, WithNormalization(false))
+			uid, err := NewUUID(tc.input, opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, uid)
+		})
+	}
+}
+
+func TestWithNamespaceNormalizesInput(t *testing.T) {
+	uid, err := NewUUID("  User@Example.com ", WithNamespace(NamespaceDNS))
+	require.NoError(t, err)
+
+	normalized, err := Normalizer("  User@Example.com ")
+	require.NoError(t, err)
+
+	assert.Equal(t, uuid.NewMD5(NamespaceDNS, []byte(normalized)), uid)
+}
+
+func TestLegacyDerivation(t *testing.T) {
+	legacy, err := New("A81758FFFE04E4F5")
+	require.NoError(t, err)
+	assert.Equal(t, "ddea575a-d5e2-3114-9267-dbead79c4ab8", legacy)
+
+	namespaced, err := New("A81758FFFE04E4F5", WithNamespace(NamespaceDNS))
+	require.NoError(t, err)
+	assert.NotEqual(t, legacy, namespaced)
+
+	restored, err := New("A81758FFFE04E4F5", WithNamespace(NamespaceDNS), WithLegacyDerivation())
+	require.NoError(t, err)
+	assert.Equal(t, legacy, restored)
+}
+
+func TestNamespaceChild(t *testing.T) {
+	acme, err := NewNamespace("acme", WithHashAlgorithm(SHA1))
+	require.NoError(t, err)
+	globex, err := NewNamespace("globex", WithHashAlgorithm(SHA1))
+	require.NoError(t, err)
+
+	assert.Equal(t, uuid.Version(5), acme.UUID().Version())
+	assert.NotEqual(t, acme.UUID(), globex.UUID())
+
+	acmeOrders, err := acme.Child("orders")
+	require.NoError(t, err)
+	globexOrders, err := globex.Child("orders")
+	require.NoError(t, err)
+
+	a, err := acmeOrders.New("order-1234")
+	require.NoError(t, err)
+	b, err := globexOrders.New("order-1234")
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	// the derivation chain is reproducible
+	again, err := NewNamespace("acme", WithHashAlgorithm(SHA1))
+	require.NoError(t, err)
+	againOrders, err := again.Child("orders")
+	require.NoError(t, err)
+	c, err := againOrders.New("order-1234")
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+}
+
+func TestNamespaceDerivationIsRFCCompatible(t *testing.T) {
+	tenant, err := NewNamespace("acme", WithHashAlgorithm(SHA1), WithNormalization(false))
+	require.NoError(t, err)
+
+	expectedTenant := uuid.NewSHA1(uuid.Nil, []byte("acme"))
+	assert.Equal(t, expectedTenant, tenant.UUID())
+
+	orders, err := tenant.Child("orders")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NewSHA1(expectedTenant, []byte("orders")), orders.UUID())
+
+	uid, err := orders.NewUUID("order-1234")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NewSHA1(orders.UUID(), []byte("order-1234")), uid)
+}
+
+func TestNamespaceFromUUID(t *testing.T) {
+	ns, err := NamespaceFromUUID(NamespaceDNS, WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS.String(), ns.String())
+
+	id, err := ns.New("www.example.com")
+	require.NoError(t, err)
+
+	expected, err := New("www.example.com", WithNamespace(NamespaceDNS), WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	sid, err := ns.NewShortID("www.example.com")
+	require.NoError(t, err)
+	parsed, err := ParseShortID(sid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, parsed.String())
+
+	_, err = NamespaceFromUUID(NamespaceDNS, WithUUIDVersion(10))
+	assert.Error(t, err)
+}
@@ -1,20 +1,60 @@
 package hashid
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/unicode/norm"
 )
 
+var spaceRegexp = regexp.MustCompile(`\s+`)
+
+// removeCharList holds the characters dropped during normalization
+const removeCharList = `@#:_~.$^()!*+'"\-`
+
 var (
-	removeCharList, _ = regexp.Compile(`[@#:_~.$^()!*+'"\\-]+`)
-	spaceRegexp       = regexp.MustCompile(`\s+`)
+	namedNormalizers = map[string]func(string) (string, error){
+		"default": Normalizer,
+		"none":    noopNormalizer,
+		"email":   EmailNormalizer,
+		"phone":   PhoneNormalizer,
+		"url":     URLNormalizer,
+		"domain":  DomainNormalizer,
+	}
+	namedNormalizersMu sync.RWMutex
 )
 
+// RegisterNormalizer makes a normalizer available by name, e.g.
+// to struct fields tagged with `hashid:"email,normalize=email"`.
+// Registering a name twice replaces the previous normalizer.
+func RegisterNormalizer(name string, normalizer func(string) (string, error)) {
+	namedNormalizersMu.Lock()
+	defer namedNormalizersMu.Unlock()
+	namedNormalizers[name] = normalizer
+}
+
+// LookupNormalizer returns the normalizer registered with name.
+func LookupNormalizer(name string) (func(string) (string, error), error) {
+	namedNormalizersMu.RLock()
+	defer namedNormalizersMu.RUnlock()
+
+	normalizer, ok := namedNormalizers[name]
+	if !ok {
+		return nil, &ConfigError{Field: "normalizer", Err: fmt.Errorf("%w: %s", ErrUnknownNormalizer, name)}
+	}
+	return normalizer, nil
+}
+
+func noopNormalizer(s string) (string, error) {
+	return s, nil
+}
+
 type normalizer struct {
 	charMap   map[string]string
 	separator string
+	pipeline  Pipeline
 }
 
 func newNormalizer(charMap map[string]string, separator string) (*normalizer, error) {
@@ -22,18 +62,19 @@ func newNormalizer(charMap map[string]string, separator string) (*normalizer, er
 		separator = "-"
 	}
 
-	var err error
+	name := "custom-charmap"
 	if charMap == nil {
-		charMap, err = GetCharMap()
-	}
-
-	if err != nil {
-		return nil, err
+		var err error
+		if charMap, err = GetCharMap(); err != nil {
+			return nil, err
+		}
+		name = "charmap"
 	}
 
 	return &normalizer{
 		charMap:   charMap,
 		separator: separator,
+		pipeline:  defaultPipeline(charMapStep(name, charMap, separator), separator),
 	}, nil
 }
 
@@ -65,35 +106,7 @@ func NormalizerWithCharMap(s string, m map[string]string) (string, error) {
 }
 
 func (n *normalizer) normalize(s string) (string, error) {
-	s = unicodeNorm(s)
-
-	var result strings.Builder
-
-	for _, ch := range s {
-		char := string(ch)
-
-		appendChar, ok := n.charMap[char]
-		if !ok {
-			appendChar = char
-		}
-
-		if appendChar == n.separator {
-			appendChar = " "
-		}
-
-		cleanChar := removeCharList.ReplaceAllString(appendChar, "")
-		result.WriteString(cleanChar)
-	}
-
-	out := result.String()
-
-	out = strings.TrimSpace(out)
-
-	out = spaceRegexp.ReplaceAllString(out, n.separator)
-
-	out = strings.ToLower(out)
-
-	return out, nil
+	return n.pipeline.Normalize(s)
 }
 
 func (n *normalizer) replaceUnicodeChars(s string) (string, error) {
@@ -104,7 +117,15 @@ func (n *normalizer) replaceUnicodeChars(s string) (string, error) {
 }
 
 func removeCharsNotAllowed(s string) string {
-	return removeCharList.ReplaceAllString(s, "")
+	if !strings.ContainsAny(s, removeCharList) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(removeCharList, r) {
+			return -1
+		}
+		return r
+	}, s)
 }
 
 func removeSpaces(s string) string {
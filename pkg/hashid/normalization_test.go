@@ -1,6 +1,7 @@
 package hashid
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -122,3 +123,29 @@ func TestReplaceUnicodeChars(t *testing.T) {
 		assert.Equal(t, val, out)
 	}
 }
+
+func TestLookupNormalizer(t *testing.T) {
+	n, err := LookupNormalizer("default")
+	require.NoError(t, err)
+	result, err := n("Hello World!")
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world", result)
+
+	n, err = LookupNormalizer("none")
+	require.NoError(t, err)
+	result, err = n("Hello World!")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World!", result)
+
+	_, err = LookupNormalizer("unknown")
+	assert.Error(t, err)
+
+	RegisterNormalizer("test-upper", func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	})
+	n, err = LookupNormalizer("test-upper")
+	require.NoError(t, err)
+	result, err = n("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", result)
+}
@@ -0,0 +1,92 @@
+package hashid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromPartsIsUnambiguous(t *testing.T) {
+	a, err := NewFromParts([]string{"a:b", "c"}, WithNormalization(false))
+	require.NoError(t, err)
+
+	b, err := NewFromParts([]string{"a", ":b c"}, WithNormalization(false))
+	require.NoError(t, err)
+
+	c, err := NewFromParts([]string{"a:bc"}, WithNormalization(false))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.NotEqual(t, b, c)
+
+	// a single part is not the same as the plain input
+	d, err := New("a:bc", WithNormalization(false))
+	require.NoError(t, err)
+	assert.NotEqual(t, c, d)
+}
+
+func TestNewFromPartsNormalizesEachPart(t *testing.T) {
+	a, err := NewFromParts([]string{"  ACME ", "Order", "1234"})
+	require.NoError(t, err)
+
+	b, err := NewFromParts([]string{"acme", "order", "1234"})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+
+	// normalization must not merge parts
+	c, err := NewFromParts([]string{"acme order", "1234"})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+func TestNewFromPartsVariants(t *testing.T) {
+	parts := []string{"tenant", "user", "42"}
+	opts := []Option{WithHashAlgorithm(SHA1)}
+
+	id, err := NewFromParts(parts, opts...)
+	require.NoError(t, err)
+
+	uid, err := NewUUIDFromParts(parts, opts...)
+	require.NoError(t, err)
+	assert.Equal(t, id, uid.String())
+	assert.Equal(t, 5, int(uid.Version()))
+
+	sid, err := NewShortIDFromParts(parts, opts...)
+	require.NoError(t, err)
+	parsed, err := ParseShortID(sid)
+	require.NoError(t, err)
+	assert.Equal(t, uid, parsed)
+
+	gen, err := NewGenerator(opts...)
+	require.NoError(t, err)
+	gid, err := gen.NewFromParts(parts)
+	require.NoError(t, err)
+	assert.Equal(t, id, gid)
+}
+
+func TestNewFromPartsErrors(t *testing.T) {
+	_, err := NewFromParts(nil)
+	assert.ErrorIs(t, err, ErrNoParts)
+
+	// custom normalizer errors are wrapped with the part index
+	failing := errors.New("failing")
+	_, err = NewFromParts([]string{"a", "b"}, WithCustomNormalizer(func(s string) (string, error) {
+		if s == "b" {
+			return "", failing
+		}
+		return s, nil
+	}))
+	assert.ErrorIs(t, err, failing)
+	assert.ErrorContains(t, err, "part 1")
+
+	var nerr *NormalizationError
+	require.ErrorAs(t, err, &nerr)
+	assert.Equal(t, "b", nerr.Input)
+
+	_, err = NewFromParts([]string{"a"}, WithHashAlgorithm(HMAC_SHA256))
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+package hashid
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxE164Digits is the maximum length of an E.164 number,
+// country calling code included
+const maxE164Digits = 15
+
+// phoneRegion holds the dialing rules of a region
+type phoneRegion struct {
+	// code is the country calling code
+	code string
+	// trunk is the prefix dialed before national numbers, e.g. 0
+	trunk string
+	// trunkDigits is set when national significant numbers can
+	// start with the digits of the trunk prefix
+	trunkDigits bool
+	// idd is the prefix dialed before international numbers
+	idd string
+	// min and max bound the length of national significant numbers
+	min, max int
+}
+
+// phoneRegions are the regions that can be used as default
+// region, keyed by ISO 3166-1 alpha-2 code
+var phoneRegions = map[string]phoneRegion{
+	"US": {code: "1", trunk: "1", idd: "011", min: 10, max: 10},
+	"CA": {code: "1", trunk: "1", idd: "011", min: 10, max: 10},
+	"MX": {code: "52", idd: "00", min: 10, max: 10},
+	"BR": {code: "55", trunk: "0", idd: "00", min: 10, max: 11},
+	"AR": {code: "54", trunk: "0", idd: "00", min: 10, max: 11},
+	"GB": {code: "44", trunk: "0", idd: "00", min: 9, max: 10},
+	"IE": {code: "353", trunk: "0", idd: "00", min: 7, max: 9},
+	"DE": {code: "49", trunk: "0", idd: "00", min: 6, max: 13},
+	"FR": {code: "33", trunk: "0", idd: "00", min: 9, max: 9},
+	"ES": {code: "34", idd: "00", min: 9, max: 9},
+	"PT": {code: "351", idd: "00", min: 9, max: 9},
+	"IT": {code: "39", idd: "00", min: 6, max: 11},
+	"NL": {code: "31", trunk: "0", idd: "00", min: 9, max: 9},
+	"BE": {code: "32", trunk: "0", idd: "00", min: 8, max: 9},
+	"CH": {code: "41", trunk: "0", idd: "00", min: 9, max: 9},
+	"AT": {code: "43", trunk: "0", idd: "00", min: 4, max: 13},
+	"DK": {code: "45", idd: "00", min: 8, max: 8},
+	"NO": {code: "47", idd: "00", min: 8, max: 8},
+	"SE": {code: "46", trunk: "0", idd: "00", min: 7, max: 10},
+	"FI": {code: "358", trunk: "0", idd: "00", min: 5, max: 12},
+	"PL": {code: "48", idd: "00", min: 9, max: 9},
+	"RU": {code: "7", trunk: "8", trunkDigits: true, idd: "810", min: 10, max: 10},
+	"TR": {code: "90", trunk: "0", idd: "00", min: 10, max: 10},
+	"IL": {code: "972", trunk: "0", idd: "00", min: 8, max: 9},
+	"AE": {code: "971", trunk: "0", idd: "00", min: 8, max: 9},
+	"ZA": {code: "27", trunk: "0", idd: "00", min: 9, max: 9},
+	"IN": {code: "91", trunk: "0", idd: "00", min: 10, max: 10},
+	"CN": {code: "86", trunk: "0", idd: "00", min: 10, max: 11},
+	"HK": {code: "852", idd: "001", min: 8, max: 8},
+	"JP": {code: "81", trunk: "0", idd: "010", min: 9, max: 10},
+	"KR": {code: "82", trunk: "0", idd: "001", min: 8, max: 10},
+	"SG": {code: "65", idd: "001", min: 8, max: 8},
+	"AU": {code: "61", trunk: "0", idd: "0011", min: 9, max: 9},
+	"NZ": {code: "64", trunk: "0", idd: "00", min: 8, max: 10},
+}
+
+// callingCodes are the assigned country calling codes, which
+// form a prefix code so a number starts with at most one of them
+var callingCodes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, code := range strings.Fields(`
+		1 7 20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49
+		51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86
+		90 91 92 93 94 95 98 211 212 213 216 218 220 221 222 223
+		224 225 226 227 228 229 230 231 232 233 234 235 236 237
+		238 239 240 241 242 243 244 245 246 247 248 249 250 251
+		252 253 254 255 256 257 258 260 261 262 263 264 265 266
+		267 268 269 290 291 297 298 299 350 351 352 353 354 355
+		356 357 358 359 370 371 372 373 374 375 376 377 378 380
+		381 382 383 385 386 387 389 420 421 423 500 501 502 503
+		504 505 506 507 508 509 590 591 592 593 594 595 596 597
+		598 599 670 672 673 674 675 676 677 678 679 680 681 682
+		683 685 686 687 688 689 690 691 692 800 808 850 852 853
+		855 856 870 878 880 881 882 883 886 888 960 961 962 963
+		964 965 966 967 968 970 971 972 973 974 975 976 977 979
+		992 993 994 995 996 998`) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+// regionsByCode holds the length rules of each calling code,
+// regions sharing a code, like US and CA, share the rules
+var regionsByCode = func() map[string]phoneRegion {
+	regions := map[string]phoneRegion{}
+	for _, region := range phoneRegions {
+		regions[region.code] = region
+	}
+	return regions
+}()
+
+// phoneSeparators are the formatting characters
+// removed from phone numbers
+const phoneSeparators = "-./()"
+
+// WithPhoneNormalization normalizes input strings as phone
+// numbers, see NewPhoneNormalizer. An unknown region is
+// reported by NewGenerator.
+//
+// Example:
+//
+//	id, _ := hashid.New("555.010.0000", hashid.WithPhoneNormalization("US"))
+//	// same ID as hashid.New("+1 (555) 010-0000", hashid.WithPhoneNormalization("US"))
+func WithPhoneNormalization(region string) Option {
+	return func(o *options) {
+		o.normalizer, o.normalizerErr = NewPhoneNormalizer(region)
+	}
+}
+
+// PhoneNormalizer converts international phone numbers, starting
+// with + or 00, to E.164. It is registered as the "phone" normalizer,
+// use NewPhoneNormalizer to also parse national numbers.
+func PhoneNormalizer(s string) (string, error) {
+	return normalizePhone(s, phoneRegion{idd: "00"})
+}
+
+// NewPhoneNormalizer returns a normalizer that converts phone numbers
+// to E.164, e.g. +15550100000. Numbers in international format, with
+// a + or the international prefix of the region, keep their country
+// code; other numbers are national numbers of the region, a leading
+// trunk prefix is removed. Formatting characters, spaces, dashes, dots,
+// slashes and parentheses, are ignored.
+//
+// Region is an ISO 3166-1 alpha-2 code such as US or GB, unknown
+// regions return a *ConfigError wrapping ErrUnknownRegion. Invalid
+// numbers return a *NormalizationError wrapping ErrInvalidPhone.
+func NewPhoneNormalizer(region string) (func(string) (string, error), error) {
+	rules, ok := phoneRegions[strings.ToUpper(region)]
+	if !ok {
+		return nil, &ConfigError{Field: "region", Err: fmt.Errorf("%w: %q", ErrUnknownRegion, region)}
+	}
+
+	return func(s string) (string, error) {
+		return normalizePhone(s, rules)
+	}, nil
+}
+
+// Phone is a pipeline step that converts phone numbers to E.164
+// using NewPhoneNormalizer, an unknown region fails on first use.
+func Phone(region string) Step {
+	normalizer, err := NewPhoneNormalizer(region)
+	return Step{
+		name: "phone",
+		args: []string{region},
+		fn: func(s string) (string, error) {
+			if err != nil {
+				return "", err
+			}
+			return normalizer(s)
+		},
+	}
+}
+
+func normalizePhone(input string, region phoneRegion) (string, error) {
+	fail := func(position int, format string, args ...any) error {
+		return &NormalizationError{
+			Input:    input,
+			Position: position,
+			Err:      fmt.Errorf("%w: "+format, append([]any{ErrInvalidPhone}, args...)...),
+		}
+	}
+
+	// fullwidth digits and signs become ASCII
+	s := norm.NFKC.String(input)
+	exact := s == input
+
+	// +44 (0)20 is a common way to write the trunk prefix
+	s = strings.Replace(s, "(0)", "", 1)
+	exact = exact && !strings.Contains(input, "(0)")
+
+	var digits strings.Builder
+	international := false
+	for i, ch := range s {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits.WriteRune(ch)
+		case ch == '+' && digits.Len() == 0 && !international:
+			international = true
+		case strings.ContainsRune(phoneSeparators, ch) || unicode.IsSpace(ch):
+		default:
+			position := -1
+			if exact {
+				position = i
+			}
+			return "", fail(position, "unexpected character %q", ch)
+		}
+	}
+
+	number := digits.String()
+	if number == "" {
+		return "", fail(-1, "no digits")
+	}
+
+	if !international && region.idd != "" && strings.HasPrefix(number, region.idd) {
+		number = number[len(region.idd):]
+		international = true
+	}
+
+	var code, national string
+	if international {
+		code = callingCode(number)
+		if code == "" {
+			return "", fail(-1, "unknown country calling code")
+		}
+		national = number[len(code):]
+	} else {
+		if region.code == "" {
+			return "", fail(-1, "a country calling code is required")
+		}
+		code = region.code
+		national = number
+	}
+
+	if rules, ok := regionsByCode[code]; ok {
+		// the trunk prefix is dialed in national numbers, and
+		// sometimes written after the country code by mistake
+		if rules.trunk != "" && strings.HasPrefix(national, rules.trunk) &&
+			(!rules.trunkDigits || len(national) > rules.max) {
+			national = national[len(rules.trunk):]
+		}
+
+		if len(national) < rules.min || len(national) > rules.max {
+			return "", fail(-1, "national number has %d digits, expected %s", len(national), lengthRange(rules.min, rules.max))
+		}
+	} else if len(national) < 4 {
+		return "", fail(-1, "national number is too short")
+	}
+
+	if len(code)+len(national) > maxE164Digits {
+		return "", fail(-1, "number is longer than %d digits", maxE164Digits)
+	}
+
+	return "+" + code + national, nil
+}
+
+// callingCode returns the country calling code number starts with
+func callingCode(number string) string {
+	for n := 1; n <= 3 && n <= len(number); n++ {
+		if callingCodes[number[:n]] {
+			return number[:n]
+		}
+	}
+	return ""
+}
+
+func lengthRange(min, max int) string {
+	if min == max {
+		return fmt.Sprint(min)
+	}
+	return fmt.Sprintf("%d to %d", min, max)
+}
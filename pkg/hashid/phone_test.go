@@ -0,0 +1,179 @@
+package hashid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneNormalizer(t *testing.T) {
+	testCases := []struct {
+		region   string
+		input    string
+		expected string
+	}{
+		{"US", "+1 (555) 010-0000", "+15550100000"},
+		{"US", "555.010.0000", "+15550100000"},
+		{"US", "(555) 010-0000", "+15550100000"},
+		{"US", "1-555-010-0000", "+15550100000"},
+		{"US", "011 44 20 7946 0000", "+442079460000"},
+		{"us", "+44 (0)20 7946 0000", "+442079460000"},
+		{"GB", "020 7946 0000", "+442079460000"},
+		{"GB", "07700 900000", "+447700900000"},
+		{"GB", "+44 020 7946 0000", "+442079460000"},
+		{"GB", "00 1 555 010 0000", "+15550100000"},
+		{"DE", "030 12345678", "+493012345678"},
+		{"DE", "+49 (30) 12345678", "+493012345678"},
+		{"FR", "01 23 45 67 89", "+33123456789"},
+		{"IT", "06 1234 5678", "+390612345678"},
+		{"IT", "+39 06 1234 5678", "+390612345678"},
+		{"RU", "8 800 555 35 35", "+78005553535"},
+		{"RU", "800 555 35 35", "+78005553535"},
+		{"JP", "03-1234-5678", "+81312345678"},
+		{"AU", "0011 1 555 010 0000", "+15550100000"},
+		{"AU", "(02) 1234 5678", "+61212345678"},
+		{"US", "+1 555 010 0000", "+15550100000"},
+		{"US", "＋１ ５５５ ０１０ ００００", "+15550100000"},
+		{"US", "+683 4002", "+6834002"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.region+" "+tc.input, func(t *testing.T) {
+			normalizer, err := NewPhoneNormalizer(tc.region)
+			require.NoError(t, err)
+
+			out, err := normalizer(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestPhoneNormalizerErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"empty", "", -1},
+		{"letters", "555-010-FLOWERS", 8},
+		{"plus in the middle", "555+0100000", 3},
+		{"extension", "555 010 0000 ext. 12", 13},
+		{"too short", "555 0100", -1},
+		{"too long", "555 010 00000", -1},
+		{"unknown calling code", "+999 1234 5678", -1},
+		{"too long for E.164", "+683 1234 5678 9012 3", -1},
+		{"short international", "+683 123", -1},
+		{"wrong length", "+44 20 7946", -1},
+	}
+
+	normalizer, err := NewPhoneNormalizer("US")
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := normalizer(tc.input)
+			assert.ErrorIs(t, err, ErrInvalidPhone)
+
+			var nerr *NormalizationError
+			require.ErrorAs(t, err, &nerr)
+			assert.Equal(t, tc.input, nerr.Input)
+			assert.Equal(t, tc.position, nerr.Position)
+		})
+	}
+}
+
+func TestPhoneNormalizerRegion(t *testing.T) {
+	_, err := NewPhoneNormalizer("XX")
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+
+	var cerr *ConfigError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "region", cerr.Field)
+
+	_, err = NewGenerator(WithPhoneNormalization("XX"))
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+
+	// without a region only international numbers are parsed
+	out, err := PhoneNormalizer("+1 (555) 010-0000")
+	require.NoError(t, err)
+	assert.Equal(t, "+15550100000", out)
+
+	out, err = PhoneNormalizer("0044 20 7946 0000")
+	require.NoError(t, err)
+	assert.Equal(t, "+442079460000", out)
+
+	_, err = PhoneNormalizer("555.010.0000")
+	assert.ErrorIs(t, err, ErrInvalidPhone)
+}
+
+func TestWithPhoneNormalization(t *testing.T) {
+	a, err := New("+1 (555) 010-0000", WithPhoneNormalization("US"))
+	require.NoError(t, err)
+
+	b, err := New("555.010.0000", WithPhoneNormalization("US"))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// the E.164 form is hashed
+	c, err := New("+15550100000", WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+
+	_, err = New("not a number", WithPhoneNormalization("US"))
+	assert.ErrorIs(t, err, ErrInvalidPhone)
+
+	n, err := LookupNormalizer("phone")
+	require.NoError(t, err)
+	out, err := n("+1 555 010 0000")
+	require.NoError(t, err)
+	assert.Equal(t, "+15550100000", out)
+}
+
+func TestWithPhoneNormalizationReplaced(t *testing.T) {
+	_, err := NewGenerator(WithPhoneNormalization("XX"))
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+
+	// the unknown region only matters while the phone normalizer is used
+	replaced := []Option{
+		WithEmailNormalization(),
+		WithURLNormalization(),
+		WithCustomNormalizer(noopNormalizer),
+		WithPipeline(Pipeline{Trim()}),
+		WithCustomCharMap(map[string]string{"a": "b"}),
+	}
+	for _, opt := range replaced {
+		_, err := NewGenerator(WithPhoneNormalization("XX"), opt)
+		assert.NoError(t, err)
+	}
+
+	_, err = NewGenerator(WithEmailNormalization(), WithPhoneNormalization("XX"))
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+}
+
+func TestPhonePipeline(t *testing.T) {
+	p, err := ParsePipeline(`trim,phone:"GB"`)
+	require.NoError(t, err)
+	assert.Equal(t, `trim,phone:"GB"`, p.String())
+
+	out, err := p.Normalize(" 020 7946 0000 ")
+	require.NoError(t, err)
+	assert.Equal(t, "+442079460000", out)
+
+	out, err = Pipeline{Phone("US")}.Normalize("555.010.0000")
+	require.NoError(t, err)
+	assert.Equal(t, "+15550100000", out)
+
+	p, err = ParsePipeline("phone")
+	require.NoError(t, err)
+	out, err = p.Normalize("+1 555 010 0000")
+	require.NoError(t, err)
+	assert.Equal(t, "+15550100000", out)
+
+	_, err = ParsePipeline(`phone:"XX"`)
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+
+	_, err = Pipeline{Phone("XX")}.Normalize("555.010.0000")
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+}
@@ -0,0 +1,373 @@
+package hashid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Step is a single normalization step of a Pipeline.
+// Steps are created with UnicodeForm, CharMap, StripChars,
+// Trim, CollapseWhitespace, CaseFold, CaseFoldWith, Phone or Func.
+type Step struct {
+	name string
+	args []string
+	fn   func(string) (string, error)
+}
+
+// Name returns the name of the step in a pipeline spec.
+func (s Step) Name() string {
+	return s.name
+}
+
+// String returns the spec of the step, e.g. `collapse:"-"`.
+func (s Step) String() string {
+	var b strings.Builder
+	b.WriteString(s.name)
+	for _, arg := range s.args {
+		b.WriteByte(':')
+		b.WriteString(strconv.Quote(arg))
+	}
+	return b.String()
+}
+
+// Pipeline normalizes input by applying its steps in order.
+// It is a plain slice, steps can be reordered, inserted or
+// removed before passing it to WithPipeline.
+//
+// Example:
+//
+//	p := append(hashid.DefaultPipeline(), hashid.Func("digits", onlyDigits))
+//	id, _ := hashid.New("input", hashid.WithPipeline(p))
+type Pipeline []Step
+
+// DefaultPipeline returns the steps of Normalizer:
+//
+//	nfc,charmap:"-",strip:"@#:_~.$^()!*+'\"\\-",trim,collapse:"-",casefold
+func DefaultPipeline() Pipeline {
+	return defaultPipeline(CharMap(nil, "-"), "-")
+}
+
+func defaultPipeline(charMap Step, separator string) Pipeline {
+	return Pipeline{
+		UnicodeForm(norm.NFC),
+		charMap,
+		StripChars(removeCharList),
+		Trim(),
+		CollapseWhitespace(separator),
+		CaseFold(),
+	}
+}
+
+// Normalize applies the steps to s. Errors are returned as
+// *NormalizationError and name the step that failed.
+func (p Pipeline) Normalize(s string) (string, error) {
+	input := s
+	for _, step := range p {
+		out, err := step.fn(s)
+		if err != nil {
+			// keep the position reported by the step
+			var nerr *NormalizationError
+			if errors.As(err, &nerr) {
+				return "", err
+			}
+			return "", &NormalizationError{Input: input, Position: -1, Err: fmt.Errorf("%s: %w", step.name, err)}
+		}
+		s = out
+	}
+	return s, nil
+}
+
+// String returns the spec of the pipeline, which ParsePipeline
+// turns back into the same pipeline.
+func (p Pipeline) String() string {
+	steps := make([]string, len(p))
+	for i, step := range p {
+		steps[i] = step.String()
+	}
+	return strings.Join(steps, ",")
+}
+
+// WithPipeline normalizes input strings with the given pipeline.
+// It takes precedence over WithCustomNormalizer and WithCustomCharMap,
+// an empty pipeline leaves the input unchanged.
+func WithPipeline(p Pipeline) Option {
+	return func(o *options) {
+		o.pipeline = p
+	}
+}
+
+var unicodeForms = map[string]norm.Form{
+	"nfc":  norm.NFC,
+	"nfd":  norm.NFD,
+	"nfkc": norm.NFKC,
+	"nfkd": norm.NFKD,
+}
+
+// UnicodeForm converts the input to the given Unicode normal form.
+func UnicodeForm(form norm.Form) Step {
+	name := ""
+	for n, f := range unicodeForms {
+		if f == form {
+			name = n
+		}
+	}
+
+	return Step{
+		name: name,
+		fn: func(s string) (string, error) {
+			return form.String(s), nil
+		},
+	}
+}
+
+// CharMap replaces each character with its mapping, a nil mapping
+// uses the default character map. Characters that are, or map to,
+// separator become spaces so that CollapseWhitespace turns them into
+// a single separator, an empty separator disables this.
+//
+// Custom mappings can not be written in a spec, they are named
+// "custom-charmap" and rejected by ParsePipeline.
+func CharMap(mapping map[string]string, separator string) Step {
+	if mapping != nil {
+		return charMapStep("custom-charmap", mapping, separator)
+	}
+
+	// load the default map on first use
+	var once sync.Once
+	var step Step
+	var err error
+	lazy := charMapStep("charmap", nil, separator)
+	lazy.fn = func(s string) (string, error) {
+		once.Do(func() {
+			var m map[string]string
+			m, err = GetCharMap()
+			step = charMapStep("charmap", m, separator)
+		})
+		if err != nil {
+			return "", err
+		}
+		return step.fn(s)
+	}
+	return lazy
+}
+
+func charMapStep(name string, mapping map[string]string, separator string) Step {
+	step := Step{name: name}
+	if separator != "" {
+		step.args = []string{separator}
+	}
+
+	step.fn = func(s string) (string, error) {
+		var result strings.Builder
+		for _, ch := range s {
+			char := string(ch)
+
+			out, ok := mapping[char]
+			if !ok {
+				out = char
+			}
+
+			if separator != "" && out == separator {
+				out = " "
+			}
+
+			result.WriteString(out)
+		}
+		return result.String(), nil
+	}
+	return step
+}
+
+// StripChars removes every occurrence of the given characters.
+func StripChars(chars string) Step {
+	return Step{
+		name: "strip",
+		args: []string{chars},
+		fn: func(s string) (string, error) {
+			if !strings.ContainsAny(s, chars) {
+				return s, nil
+			}
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune(chars, r) {
+					return -1
+				}
+				return r
+			}, s), nil
+		},
+	}
+}
+
+// Trim removes leading and trailing whitespace.
+func Trim() Step {
+	return Step{
+		name: "trim",
+		fn: func(s string) (string, error) {
+			return strings.TrimSpace(s), nil
+		},
+	}
+}
+
+// CollapseWhitespace replaces each run of whitespace with separator.
+func CollapseWhitespace(separator string) Step {
+	return Step{
+		name: "collapse",
+		args: []string{separator},
+		fn: func(s string) (string, error) {
+			return spaceRegexp.ReplaceAllString(s, separator), nil
+		},
+	}
+}
+
+// CaseFold lower cases the input.
+func CaseFold() Step {
+	return Step{
+		name: "casefold",
+		fn: func(s string) (string, error) {
+			return strings.ToLower(s), nil
+		},
+	}
+}
+
+// Func wraps a custom function as a step. The name is used in
+// specs, ParsePipeline resolves it with LookupNormalizer so the
+// function must be registered with RegisterNormalizer to be parsed.
+func Func(name string, fn func(string) (string, error)) Step {
+	return Step{name: name, fn: fn}
+}
+
+// ParsePipeline builds a pipeline from a spec, a comma separated
+// list of steps with optional quoted arguments, as returned by
+// Pipeline.String:
+//
+//	nfkc,trim,collapse:"_",casefold
+//
+// Step names are nfc, nfd, nfkc, nfkd, charmap[:separator],
+// strip:chars, trim, collapse:separator, casefold[:mode[:locale]] and
+// phone[:region].
+// Other names are looked up with LookupNormalizer.
+func ParsePipeline(spec string) (Pipeline, error) {
+	p := Pipeline{}
+
+	rest := strings.TrimSpace(spec)
+	for rest != "" {
+		pos := len(spec) - len(rest)
+
+		end := strings.IndexAny(rest, ":,")
+		if end < 0 {
+			end = len(rest)
+		}
+		name := strings.TrimSpace(rest[:end])
+		rest = rest[end:]
+
+		var args []string
+		for strings.HasPrefix(rest, ":") {
+			quoted, err := strconv.QuotedPrefix(rest[1:])
+			if err != nil {
+				return nil, pipelineError(fmt.Errorf("%w: invalid argument at position %d", ErrInvalidPipeline, len(spec)-len(rest)+1))
+			}
+			arg, _ := strconv.Unquote(quoted)
+			args = append(args, arg)
+			rest = rest[1+len(quoted):]
+		}
+
+		step, err := newStep(name, args)
+		if err != nil {
+			return nil, pipelineError(fmt.Errorf("%w at position %d", err, pos))
+		}
+		p = append(p, step)
+
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+		if rest[0] != ',' {
+			return nil, pipelineError(fmt.Errorf("%w: expected ',' at position %d", ErrInvalidPipeline, len(spec)-len(rest)))
+		}
+		rest = strings.TrimSpace(rest[1:])
+		if rest == "" {
+			return nil, pipelineError(fmt.Errorf("%w: trailing ','", ErrInvalidPipeline))
+		}
+	}
+
+	return p, nil
+}
+
+// newStep returns the step with the given spec name and arguments
+func newStep(name string, args []string) (Step, error) {
+	arity := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("%w: %s takes %d to %d arguments, got %d", ErrInvalidPipeline, name, min, max, len(args))
+		}
+		return nil
+	}
+
+	if form, ok := unicodeForms[name]; ok {
+		return UnicodeForm(form), arity(0, 0)
+	}
+
+	switch name {
+	case "":
+		return Step{}, fmt.Errorf("%w: missing step name", ErrInvalidPipeline)
+	case "charmap":
+		if err := arity(0, 1); err != nil {
+			return Step{}, err
+		}
+		separator := ""
+		if len(args) == 1 {
+			separator = args[0]
+		}
+		return CharMap(nil, separator), nil
+	case "strip":
+		if err := arity(1, 1); err != nil {
+			return Step{}, err
+		}
+		return StripChars(args[0]), nil
+	case "trim":
+		return Trim(), arity(0, 0)
+	case "collapse":
+		if err := arity(1, 1); err != nil {
+			return Step{}, err
+		}
+		return CollapseWhitespace(args[0]), nil
+	case "casefold":
+		if err := arity(0, 2); err != nil {
+			return Step{}, err
+		}
+		return newCaseFoldStep(args)
+	case "phone":
+		if err := arity(0, 1); err != nil {
+			return Step{}, err
+		}
+		if len(args) == 0 {
+			return Func(name, PhoneNormalizer), nil
+		}
+		if _, err := NewPhoneNormalizer(args[0]); err != nil {
+			return Step{}, errors.Unwrap(err)
+		}
+		return Phone(args[0]), nil
+	}
+
+	if err := arity(0, 0); err != nil {
+		return Step{}, err
+	}
+
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return Step{}, fmt.Errorf("%w: invalid step name %q", ErrInvalidPipeline, name)
+	}
+
+	fn, err := LookupNormalizer(name)
+	if err != nil {
+		return Step{}, errors.Unwrap(err)
+	}
+	return Func(name, fn), nil
+}
+
+func pipelineError(err error) error {
+	return &ConfigError{Field: "pipeline", Err: err}
+}
@@ -0,0 +1,223 @@
+package hashid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+var pipelineInputs = []string{
+	"Hello World!",
+	"Hellö Wørld!",
+	"special@#-$-%^-&*-chars",
+	"Multiple   Spaces",
+	"  Trim Spaces  ",
+	"User@Example.com",
+	"é ₹ © ß",
+	"under_score - dash",
+	"",
+}
+
+func TestDefaultPipeline(t *testing.T) {
+	p := DefaultPipeline()
+
+	for _, input := range pipelineInputs {
+		expected, err := Normalizer(input)
+		require.NoError(t, err)
+
+		out, err := p.Normalize(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, out, input)
+	}
+
+	assert.Equal(t, `nfc,charmap:"-",strip:"@#:_~.$^()!*+'\"\\-",trim,collapse:"-",casefold`, p.String())
+}
+
+func TestDefaultPipelineSeparator(t *testing.T) {
+	p := DefaultPipeline()
+	p[1] = CharMap(nil, "_")
+	p[4] = CollapseWhitespace("_")
+
+	for _, input := range pipelineInputs {
+		expected, err := NormalizerWithSeparator(input, "_")
+		require.NoError(t, err)
+
+		out, err := p.Normalize(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, out, input)
+	}
+}
+
+func TestPipelineSteps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		step     Step
+		input    string
+		expected string
+	}{
+		{"nfc", UnicodeForm(norm.NFC), "é", "é"},
+		{"nfkd", UnicodeForm(norm.NFKD), "ﬁ", "fi"},
+		{"charmap", CharMap(map[string]string{"ß": "ss", "/": "-"}, "-"), "ß/a", "ss a"},
+		{"charmap without separator", CharMap(map[string]string{"/": "-"}, ""), "a/b", "a-b"},
+		{"default charmap", CharMap(nil, ""), "©", "(c)"},
+		{"strip", StripChars("()"), "(c)", "c"},
+		{"trim", Trim(), " \ta b\n", "a b"},
+		{"collapse", CollapseWhitespace("_"), "a  \t b", "a_b"},
+		{"casefold", CaseFold(), "ÀB", "àb"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Pipeline{tc.step}.Normalize(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestPipelineOrder(t *testing.T) {
+	digits := Func("digits", func(s string) (string, error) {
+		return strings.Map(func(r rune) rune {
+			if r < '0' || r > '9' {
+				return -1
+			}
+			return r
+		}, s), nil
+	})
+
+	// trimming after collapsing keeps the trailing separator
+	out, err := Pipeline{CollapseWhitespace("-"), Trim()}.Normalize("a b ")
+	require.NoError(t, err)
+	assert.Equal(t, "a-b-", out)
+
+	out, err = append(Pipeline{digits}, DefaultPipeline()...).Normalize("+1 (555) 010-9999")
+	require.NoError(t, err)
+	assert.Equal(t, "15550109999", out)
+}
+
+func TestPipelineErrors(t *testing.T) {
+	failure := errors.New("failure")
+	p := Pipeline{Trim(), Func("fail", func(string) (string, error) {
+		return "", failure
+	})}
+
+	_, err := p.Normalize(" input ")
+	assert.ErrorIs(t, err, failure)
+
+	var nerr *NormalizationError
+	require.ErrorAs(t, err, &nerr)
+	assert.Equal(t, " input ", nerr.Input)
+	assert.Equal(t, "normalization error: fail: failure", err.Error())
+
+	_, err = New("input", WithPipeline(p))
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestParsePipeline(t *testing.T) {
+	RegisterNormalizer("test-reverse", func(s string) (string, error) {
+		return reverse(s), nil
+	})
+
+	for _, spec := range []string{
+		DefaultPipeline().String(),
+		`nfkc,trim,collapse:"_",casefold`,
+		`strip:"\",:",test-reverse`,
+		`charmap`,
+		``,
+	} {
+		p, err := ParsePipeline(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, spec, p.String())
+	}
+
+	p, err := ParsePipeline(` trim , collapse:"." , test-reverse `)
+	require.NoError(t, err)
+	out, err := p.Normalize(" ab cd ")
+	require.NoError(t, err)
+	assert.Equal(t, "dc.ba", out)
+
+	p, err = ParsePipeline(DefaultPipeline().String())
+	require.NoError(t, err)
+	for _, input := range pipelineInputs {
+		expected, err := Normalizer(input)
+		require.NoError(t, err)
+		out, err := p.Normalize(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, out)
+	}
+}
+
+func TestParsePipelineErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		spec   string
+		target error
+	}{
+		{"unknown step", "trim,unknown", ErrUnknownNormalizer},
+		{"custom charmap", `custom-charmap:"-"`, ErrInvalidPipeline},
+		{"missing argument", "collapse", ErrInvalidPipeline},
+		{"extra argument", `trim:"x"`, ErrInvalidPipeline},
+		{"unquoted argument", "collapse:-", ErrInvalidPipeline},
+		{"unterminated argument", `collapse:"-`, ErrInvalidPipeline},
+		{"empty step", "trim,,casefold", ErrInvalidPipeline},
+		{"trailing comma", "trim,", ErrInvalidPipeline},
+		{"missing comma", `collapse:"-" trim`, ErrInvalidPipeline},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePipeline(tc.spec)
+			assert.ErrorIs(t, err, tc.target)
+
+			var cerr *ConfigError
+			require.ErrorAs(t, err, &cerr)
+			assert.Equal(t, "pipeline", cerr.Field)
+		})
+	}
+}
+
+func TestWithPipeline(t *testing.T) {
+	expected, err := New("User@Example.com")
+	require.NoError(t, err)
+
+	id, err := New("User@Example.com", WithPipeline(DefaultPipeline()))
+	require.NoError(t, err)
+	assert.Equal(t, expected, id)
+
+	// the pipeline takes precedence over the normalizer and charmap
+	p := Pipeline{Trim(), CaseFold()}
+	opts := []Option{
+		WithPipeline(p),
+		WithCustomNormalizer(func(s string) (string, error) {
+			return strings.ToUpper(s), nil
+		}),
+		WithCustomCharMap(map[string]string{"a": "b"}),
+	}
+
+	gen, err := NewGenerator(opts...)
+	require.NoError(t, err)
+	out, err := gen.Normalize(" User@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", out)
+}
+
+func TestPipelineFingerprint(t *testing.T) {
+	def, err := NewGenerator()
+	require.NoError(t, err)
+
+	gen, err := NewGenerator(WithPipeline(DefaultPipeline()))
+	require.NoError(t, err)
+	assert.Equal(t, def.Fingerprint(), gen.Fingerprint())
+
+	gen, err = NewGenerator(WithPipeline(Pipeline{Trim(), CaseFold()}))
+	require.NoError(t, err)
+	assert.NotEqual(t, def.Fingerprint(), gen.Fingerprint())
+
+	other, err := NewGenerator(WithPipeline(Pipeline{CaseFold(), Trim()}))
+	require.NoError(t, err)
+	assert.NotEqual(t, gen.Fingerprint(), other.Fingerprint())
+}
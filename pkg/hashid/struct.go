@@ -0,0 +1,293 @@
+package hashid
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const structTag = "hashid"
+
+// structField describes a tagged struct field
+type structField struct {
+	index      []int
+	key        string
+	order      int
+	normalizer string
+}
+
+// structFieldsCache holds the parsed fields of each struct type
+var structFieldsCache sync.Map
+
+// FromStruct generates a UUID string from the fields of v tagged
+// with `hashid`. v must be a struct or a pointer to a struct.
+//
+// The tag value is the field key, optionally followed by:
+//   - order=N: position of the field in the canonical representation,
+//     fields with the same order are sorted by key.
+//   - normalize=name: normalizer used for this field instead of the
+//     configured one, see RegisterNormalizer. The name is looked up
+//     on each call, so a normalizer registered again is used.
+//
+// Fields tagged with "-" are ignored. Keys and values are combined
+// in a canonical representation, so the same struct values always
+// produce the same ID regardless of field declaration order.
+//
+// Example:
+//
+//	type User struct {
+//		Tenant string `hashid:"tenant,order=1"`
+//		Email  string `hashid:"email,order=2,normalize=none"`
+//		Name   string
+//	}
+//
+//	id, err := hashid.FromStruct(User{Tenant: "acme", Email: "j.doe@example.com"})
+func FromStruct(v any, opts ...Option) (string, error) {
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		return "", err
+	}
+	return gen.FromStruct(v)
+}
+
+// FromStructUUID generates a uuid.UUID from the fields of v
+// tagged with `hashid`. See FromStruct.
+func FromStructUUID(v any, opts ...Option) (uuid.UUID, error) {
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gen.FromStructUUID(v)
+}
+
+// FromStruct generates a UUID string from the fields of v
+// tagged with `hashid`. See FromStruct.
+func (g *Generator) FromStruct(v any) (string, error) {
+	uid, err := g.FromStructUUID(v)
+	if err != nil {
+		return "", err
+	}
+	return uid.String(), nil
+}
+
+// FromStructUUID generates a uuid.UUID from the fields of v
+// tagged with `hashid`. See FromStruct.
+func (g *Generator) FromStructUUID(v any) (uuid.UUID, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return uuid.Nil, fmt.Errorf("nil pointer passed to FromStruct")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return uuid.Nil, fmt.Errorf("FromStruct expects a struct, got %s", rv.Kind())
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	parts := make([]string, 0, len(fields)*2)
+	for _, field := range fields {
+		fv, err := rv.FieldByIndexErr(field.index)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("field %s: %w", field.key, err)
+		}
+
+		value, err := fieldString(fv)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("field %s: %w", field.key, err)
+		}
+
+		switch {
+		case field.normalizer != "":
+			var normalizer func(string) (string, error)
+			if normalizer, err = LookupNormalizer(field.normalizer); err == nil {
+				value, err = normalizer(value)
+			}
+		case g.config.normalize:
+			value, err = g.normalizer(value)
+		}
+
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("field %s: %w", field.key, err)
+		}
+
+		parts = append(parts, field.key, value)
+	}
+
+	return g.hash(encodeParts(parts))
+}
+
+func structFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField), nil
+	}
+
+	fields, err := parseStructFields(t, nil, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("struct %s has no fields tagged with %q", t, structTag)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].order != fields[j].order {
+			return fields[i].order < fields[j].order
+		}
+		return fields[i].key < fields[j].key
+	})
+
+	for i := 1; i < len(fields); i++ {
+		if fields[i].key == fields[i-1].key {
+			return nil, fmt.Errorf("struct %s has duplicated key %q", t, fields[i].key)
+		}
+	}
+
+	structFieldsCache.Store(t, fields)
+
+	return fields, nil
+}
+
+// parseStructFields returns the tagged fields of t, path holds the
+// struct types being walked so self embedding types are skipped
+func parseStructFields(t reflect.Type, index []int, path map[reflect.Type]bool) ([]structField, error) {
+	var fields []structField
+
+	path[t] = true
+	defer delete(path, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(structTag)
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		// walk into embedded structs that are not tagged themselves
+		if !tagged {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if sf.Anonymous && ft.Kind() == reflect.Struct && !path[ft] {
+				embedded, err := parseStructFields(ft, fieldIndex, path)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("field %s is tagged but not exported", sf.Name)
+		}
+
+		field, err := parseStructTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+
+		if field.key == "" {
+			field.key = sf.Name
+		}
+		field.index = fieldIndex
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func parseStructTag(tag string) (structField, error) {
+	field := structField{}
+
+	key, rest, _ := strings.Cut(tag, ",")
+	field.key = strings.TrimSpace(key)
+
+	if rest == "" {
+		return field, nil
+	}
+
+	for _, opt := range strings.Split(rest, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(opt), "=")
+		if !ok {
+			return field, fmt.Errorf("invalid tag option %q", opt)
+		}
+
+		switch name {
+		case "order":
+			order, err := strconv.Atoi(value)
+			if err != nil {
+				return field, fmt.Errorf("invalid order %q: %w", value, err)
+			}
+			field.order = order
+		case "normalize":
+			if _, err := LookupNormalizer(value); err != nil {
+				return field, err
+			}
+			field.normalizer = value
+		default:
+			return field, fmt.Errorf("unknown tag option %q", name)
+		}
+	}
+
+	return field, nil
+}
+
+func fieldString(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", fmt.Errorf("value is nil")
+		}
+		v = v.Elem()
+	}
+
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case encoding.TextMarshaler:
+			b, err := i.MarshalText()
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		case fmt.Stringer:
+			return i.String(), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,215 @@
+package hashid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUser struct {
+	Email    string `hashid:"email,order=2"`
+	Tenant   string `hashid:"tenant,order=1"`
+	Name     string
+	Internal string `hashid:"-"`
+}
+
+type testUserReordered struct {
+	Tenant string `hashid:"tenant,order=1"`
+	Email  string `hashid:"email,order=2"`
+}
+
+type testBase struct {
+	Tenant string `hashid:"tenant"`
+}
+
+type testEmbedded struct {
+	testBase
+	ID    int       `hashid:"id"`
+	Ref   uuid.UUID `hashid:"ref"`
+	Admin bool      `hashid:"admin"`
+}
+
+type testNode struct {
+	*testNode
+	X string `hashid:"x"`
+}
+
+type testLeft struct {
+	*testRight
+	L string `hashid:"l"`
+}
+
+type testRight struct {
+	*testLeft
+	R string `hashid:"r"`
+}
+
+func TestFromStruct(t *testing.T) {
+	a, err := FromStruct(testUser{Tenant: "acme", Email: "J.Doe@Example.com", Name: "John"})
+	require.NoError(t, err)
+
+	// untagged fields do not affect the ID
+	b, err := FromStruct(&testUser{Tenant: "ACME", Email: "j.doe@example.com", Name: "Jane", Internal: "x"})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// declaration order does not matter
+	c, err := FromStruct(testUserReordered{Tenant: "acme", Email: "j.doe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+
+	// matches the canonical composite key
+	expected, err := NewFromParts([]string{"tenant", "acme", "email", "j.doe@example.com"},
+		WithCustomNormalizer(func(s string) (string, error) {
+			if s == "tenant" || s == "email" {
+				return s, nil
+			}
+			return Normalizer(s)
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, expected, a)
+
+	d, err := FromStruct(testUser{Tenant: "globex", Email: "j.doe@example.com"})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, d)
+}
+
+func TestFromStructFieldNormalizer(t *testing.T) {
+	type account struct {
+		Login string `hashid:"login,normalize=none"`
+	}
+
+	a, err := FromStructUUID(account{Login: "J.Doe"})
+	require.NoError(t, err)
+	b, err := FromStructUUID(account{Login: "j.doe"})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	type unknown struct {
+		Login string `hashid:"login,normalize=missing"`
+	}
+	_, err = FromStruct(unknown{Login: "x"})
+	assert.Error(t, err)
+}
+
+func TestFromStructEmailNormalizer(t *testing.T) {
+	type user struct {
+		Email string `hashid:"email,normalize=email"`
+	}
+
+	a, err := FromStructUUID(user{Email: "J.Doe@Example.com"})
+	require.NoError(t, err)
+	b, err := FromStructUUID(user{Email: "j.doe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = FromStruct(user{Email: "not an email"})
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+}
+
+func TestFromStructRegisterNormalizerAgain(t *testing.T) {
+	type record struct {
+		Code string `hashid:"code,normalize=test-struct-replaced"`
+	}
+
+	RegisterNormalizer("test-struct-replaced", func(s string) (string, error) {
+		return s, nil
+	})
+	before, err := FromStructUUID(record{Code: "ABC"})
+	require.NoError(t, err)
+
+	// the type is cached, the new normalizer is still used
+	RegisterNormalizer("test-struct-replaced", func(s string) (string, error) {
+		return strings.ToLower(s), nil
+	})
+	after, err := FromStructUUID(record{Code: "ABC"})
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+
+	lower, err := FromStructUUID(record{Code: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, lower, after)
+}
+
+func TestFromStructTypes(t *testing.T) {
+	ref := uuid.MustParse("2ed6657d-e927-568b-95e1-2665a8aea6a2")
+	v := testEmbedded{testBase: testBase{Tenant: "acme"}, ID: 42, Ref: ref, Admin: true}
+
+	gen, err := NewGenerator(WithHashAlgorithm(SHA1))
+	require.NoError(t, err)
+
+	uid, err := gen.FromStructUUID(v)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(5), uid.Version())
+
+	id, err := gen.FromStruct(&v)
+	require.NoError(t, err)
+	assert.Equal(t, uid.String(), id)
+
+	v.ID = 43
+	other, err := gen.FromStructUUID(v)
+	require.NoError(t, err)
+	assert.NotEqual(t, uid, other)
+}
+
+func TestFromStructSelfEmbedding(t *testing.T) {
+	// the embedded type is already on the path and is skipped
+	a, err := FromStructUUID(testNode{X: "a"})
+	require.NoError(t, err)
+	b, err := FromStructUUID(&testNode{testNode: &testNode{X: "b"}, X: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// mutually embedding types are walked once
+	c, err := FromStructUUID(testLeft{testRight: &testRight{R: "r"}, L: "l"})
+	require.NoError(t, err)
+	d, err := FromStructUUID(testLeft{testRight: &testRight{R: "other"}, L: "l"})
+	require.NoError(t, err)
+	assert.NotEqual(t, c, d)
+}
+
+func TestFromStructErrors(t *testing.T) {
+	type untagged struct {
+		Name string
+	}
+
+	type duplicated struct {
+		A string `hashid:"key"`
+		B string `hashid:"key"`
+	}
+
+	type badOrder struct {
+		A string `hashid:"key,order=first"`
+	}
+
+	type nilPointer struct {
+		A *string `hashid:"a"`
+	}
+
+	type unsupported struct {
+		A map[string]string `hashid:"a"`
+	}
+
+	testCases := []struct {
+		name  string
+		input any
+	}{
+		{"Not a struct", "string"},
+		{"Nil pointer", (*testUser)(nil)},
+		{"No tagged fields", untagged{Name: "x"}},
+		{"Duplicated keys", duplicated{}},
+		{"Invalid order", badOrder{}},
+		{"Nil field", nilPointer{}},
+		{"Unsupported field type", unsupported{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := FromStruct(tc.input)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package hashid
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// defaultPorts are removed from URLs of the matching scheme
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+// trackingParams are removed by URLStripTracking, a trailing
+// "*" matches every parameter with the prefix
+var trackingParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid",
+	"yclid", "igshid", "twclid", "mc_cid", "mc_eid", "_ga", "_gl", "_hsenc",
+	"_hsmi", "mkt_tok", "oly_anon_id", "oly_enc_id", "vero_id",
+}
+
+type urlOptions struct {
+	defaultScheme string
+	sortQuery     bool
+	stripParams   []string
+	stripFragment bool
+}
+
+// URLOption configures NewURLNormalizer.
+type URLOption func(*urlOptions)
+
+// URLDefaultScheme is prepended to inputs without a scheme, e.g.
+// "example.com/page", which are rejected by default.
+func URLDefaultScheme(scheme string) URLOption {
+	return func(o *urlOptions) {
+		o.defaultScheme = strings.ToLower(scheme)
+	}
+}
+
+// URLSortQuery sorts query parameters by name. Parameters with the
+// same name keep their relative order, since it can be significant.
+func URLSortQuery() URLOption {
+	return func(o *urlOptions) {
+		o.sortQuery = true
+	}
+}
+
+// URLStripParams removes the named query parameters, a trailing
+// "*" matches every parameter with the prefix, e.g. "utm_*".
+func URLStripParams(names ...string) URLOption {
+	return func(o *urlOptions) {
+		o.stripParams = append(o.stripParams, names...)
+	}
+}
+
+// URLStripTracking removes well known tracking parameters such
+// as utm_source, fbclid or gclid.
+func URLStripTracking() URLOption {
+	return URLStripParams(trackingParams...)
+}
+
+// URLStripFragment removes the fragment, which does not change
+// the resource a URL points to.
+func URLStripFragment() URLOption {
+	return func(o *urlOptions) {
+		o.stripFragment = true
+	}
+}
+
+// WithURLNormalization normalizes input strings as URLs,
+// see NewURLNormalizer.
+//
+// Example:
+//
+//	id, _ := hashid.New("HTTP://Example.COM:80/a/./b/../c?utm_source=x",
+//		hashid.WithURLNormalization(hashid.URLStripTracking()))
+//	// same ID as "http://example.com/a/c"
+func WithURLNormalization(opts ...URLOption) Option {
+	return func(o *options) {
+		o.normalizer, o.normalizerErr = NewURLNormalizer(opts...), nil
+	}
+}
+
+// URLNormalizer normalizes a URL with the default options of
+// NewURLNormalizer. It is registered as the "url" normalizer.
+func URLNormalizer(s string) (string, error) {
+	return defaultURLNormalizer(s)
+}
+
+var defaultURLNormalizer = NewURLNormalizer()
+
+// NewURLNormalizer returns a normalizer that applies the syntax
+// based normalization of RFC 3986 section 6.2.2 to absolute URLs,
+// instead of the generic Normalizer which strips ":", "." and "~":
+//  1. lower case the scheme and host, IDN hosts are converted
+//     to punycode
+//  2. remove the default port of the scheme
+//  3. decode percent-encoded unreserved characters, upper case
+//     the remaining escapes and escape invalid characters
+//  4. remove "." and ".." path segments, an empty path is "/"
+//  5. optionally sort and strip query parameters, see
+//     URLSortQuery, URLStripParams and URLStripTracking
+//
+// Invalid URLs return a *NormalizationError wrapping ErrInvalidURL.
+func NewURLNormalizer(opts ...URLOption) func(string) (string, error) {
+	var config urlOptions
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(s string) (string, error) {
+		return normalizeURL(s, config)
+	}
+}
+
+// DomainNormalizer lower cases a domain name, removes the trailing
+// dot and converts IDNs to punycode. It is registered as the "domain"
+// normalizer. Invalid domains return a *NormalizationError wrapping
+// ErrInvalidURL.
+func DomainNormalizer(s string) (string, error) {
+	domain, err := parseDomain(strings.TrimSpace(s))
+	if err != nil {
+		return "", &NormalizationError{Input: s, Position: -1, Err: fmt.Errorf("%w: %s", ErrInvalidURL, err.msg)}
+	}
+	return domain, nil
+}
+
+func normalizeURL(input string, config urlOptions) (string, error) {
+	fail := func(err error) error {
+		return &NormalizationError{Input: input, Position: -1, Err: fmt.Errorf("%w: %w", ErrInvalidURL, err)}
+	}
+
+	s := strings.TrimSpace(input)
+	if config.defaultScheme != "" && !hasScheme(s) {
+		s = config.defaultScheme + "://" + strings.TrimPrefix(s, "//")
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return "", fail(err)
+	}
+
+	if u.Scheme == "" {
+		return "", fail(errors.New("URL is not absolute"))
+	}
+
+	var b strings.Builder
+	b.WriteString(u.Scheme)
+	b.WriteByte(':')
+
+	if u.Opaque != "" {
+		// e.g. mailto:jdoe@example.com
+		b.WriteString(normalizePercent(u.Opaque, isPathChar))
+	} else {
+		if u.Host != "" || strings.HasPrefix(s[len(u.Scheme)+1:], "//") {
+			b.WriteString("//")
+			if err := writeAuthority(&b, u); err != nil {
+				return "", fail(err)
+			}
+		}
+
+		path := removeDotSegments(normalizePercent(u.EscapedPath(), isPathChar))
+		if path == "" && u.Host != "" {
+			path = "/"
+		}
+		b.WriteString(path)
+	}
+
+	if query := normalizeQuery(u.RawQuery, config); query != "" {
+		b.WriteByte('?')
+		b.WriteString(query)
+	}
+
+	if u.Fragment != "" && !config.stripFragment {
+		b.WriteByte('#')
+		b.WriteString(normalizePercent(u.EscapedFragment(), isQueryChar))
+	}
+
+	return b.String(), nil
+}
+
+// hasScheme reports whether s starts with a URL scheme
+func hasScheme(s string) bool {
+	i := strings.Index(s, ":")
+	if i <= 0 {
+		return false
+	}
+
+	for j, ch := range s[:i] {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z':
+		case j > 0 && (ch >= '0' && ch <= '9' || ch == '+' || ch == '-' || ch == '.'):
+		default:
+			return false
+		}
+	}
+
+	rest := s[i+1:]
+	if strings.HasPrefix(rest, "//") {
+		return true
+	}
+
+	// "example.com:8080" and "localhost:8080" are a host and port
+	return !strings.Contains(s[:i], ".") && (rest == "" || rest[0] < '0' || rest[0] > '9')
+}
+
+// writeAuthority writes the normalized user info, host and port
+func writeAuthority(b *strings.Builder, u *url.URL) error {
+	if u.User != nil {
+		b.WriteString(normalizePercent(u.User.String(), isUserInfoChar))
+		b.WriteByte('@')
+	}
+
+	host := u.Hostname()
+	switch {
+	case strings.Contains(host, ":"):
+		// IPv6 literal
+		b.WriteString("[" + strings.ToLower(host) + "]")
+	case host != "":
+		domain, err := parseDomain(host)
+		if err != nil {
+			return errors.New(err.msg)
+		}
+		b.WriteString(domain)
+	}
+
+	if port := strings.TrimLeft(u.Port(), "0"); port != "" && port != defaultPorts[u.Scheme] {
+		b.WriteByte(':')
+		b.WriteString(port)
+	}
+	return nil
+}
+
+// normalizeQuery normalizes the escapes of each parameter,
+// strips and sorts parameters as configured
+func normalizeQuery(query string, config urlOptions) string {
+	if query == "" {
+		return ""
+	}
+
+	params := strings.Split(query, "&")
+	kept := params[:0]
+	for _, param := range params {
+		if param == "" {
+			continue
+		}
+
+		param = normalizePercent(param, isQueryChar)
+		if matchParam(paramName(param), config.stripParams) {
+			continue
+		}
+		kept = append(kept, param)
+	}
+
+	if config.sortQuery {
+		sort.SliceStable(kept, func(i, j int) bool {
+			return paramName(kept[i]) < paramName(kept[j])
+		})
+	}
+	return strings.Join(kept, "&")
+}
+
+func paramName(param string) string {
+	name, _, _ := strings.Cut(param, "=")
+	return name
+}
+
+// matchParam reports whether name matches one of the patterns
+func matchParam(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+
+	if unescaped, err := url.QueryUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// normalizePercent decodes escaped unreserved characters, upper
+// cases the other escapes and escapes the characters not allowed
+func normalizePercent(s string, allowed func(byte) bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			v := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if isUnreserved(v) {
+				b.WriteByte(v)
+			} else {
+				b.WriteByte('%')
+				b.WriteByte(upperHex[v>>4])
+				b.WriteByte(upperHex[v&15])
+			}
+			i += 2
+		case c != '%' && allowed(c):
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(upperHex[c>>4])
+			b.WriteByte(upperHex[c&15])
+		}
+	}
+	return b.String()
+}
+
+// removeDotSegments implements RFC 3986 section 5.2.4
+func removeDotSegments(path string) string {
+	var out strings.Builder
+	removeLast := func() {
+		s := out.String()
+		out.Reset()
+		if i := strings.LastIndexByte(s, '/'); i > 0 {
+			out.WriteString(s[:i])
+		}
+	}
+
+	in := path
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			removeLast()
+		case in == "/..":
+			in = "/"
+			removeLast()
+		case in == "." || in == "..":
+			in = ""
+		default:
+			end := strings.IndexByte(in[1:], '/') + 1
+			if end == 0 {
+				end = len(in)
+			}
+			out.WriteString(in[:end])
+			in = in[end:]
+		}
+	}
+	return out.String()
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	}
+	return c - '0'
+}
+
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isSubDelim(c byte) bool {
+	return strings.IndexByte("!$&'()*+,;=", c) >= 0
+}
+
+func isUserInfoChar(c byte) bool {
+	return isUnreserved(c) || isSubDelim(c) || c == ':'
+}
+
+func isPathChar(c byte) bool {
+	return isUserInfoChar(c) || c == '@' || c == '/'
+}
+
+func isQueryChar(c byte) bool {
+	return isPathChar(c) || c == '?'
+}
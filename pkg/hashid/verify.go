@@ -0,0 +1,90 @@
+package hashid
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// ID is either a UUID or its string form, a canonical
+// UUID or a short ID generated by NewShortID.
+type ID interface {
+	~string | uuid.UUID
+}
+
+// Verify reports whether id was generated from input with
+// the given options. The id can be a uuid.UUID, a UUID string
+// or a short ID. IDs are compared in constant time so that
+// verifying HMAC based IDs does not leak timing information.
+//
+// Example:
+//
+//	ok, err := hashid.Verify("user@example.com", storedID,
+//		hashid.WithHMACKey(key))
+func Verify[T ID](input string, id T, opts ...Option) (bool, error) {
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := any(id).(type) {
+	case uuid.UUID:
+		return gen.VerifyUUID(input, v)
+	default:
+		// any type with an underlying string
+		return gen.Verify(input, reflect.ValueOf(v).String())
+	}
+}
+
+// Verify reports whether id, a UUID string or a short ID,
+// was generated from input.
+func (g *Generator) Verify(input, id string) (bool, error) {
+	uid, err := parseID(id, g.config.shortEncoding)
+	if err != nil {
+		return false, err
+	}
+	return g.VerifyUUID(input, uid)
+}
+
+// VerifyUUID reports whether id was generated from input.
+func (g *Generator) VerifyUUID(input string, id uuid.UUID) (bool, error) {
+	uid, err := g.NewUUID(input)
+	if err != nil {
+		return false, err
+	}
+	return equalUUID(uid, id), nil
+}
+
+// ParseID parses a canonical UUID string or a short ID
+// generated by NewShortID, using the encoding set with
+// WithShortEncoding. Errors are of type *ParseError and
+// wrap ErrInvalidID.
+func ParseID(id string, opts ...Option) (uuid.UUID, error) {
+	config := defaultOptions()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return parseID(id, config.shortEncoding)
+}
+
+func parseID(id string, enc Encoding) (uuid.UUID, error) {
+	if uid, err := uuid.Parse(id); err == nil {
+		return uid, nil
+	}
+
+	if enc == nil {
+		return uuid.Nil, encodingError(fmt.Errorf("%w: encoding is nil", ErrUnknownEncoding))
+	}
+
+	uid, err := enc.Decode(id)
+	if err != nil {
+		return uuid.Nil, &ParseError{Input: id, Position: -1, Err: ErrInvalidID}
+	}
+	return uid, nil
+}
+
+func equalUUID(a, b uuid.UUID) bool {
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
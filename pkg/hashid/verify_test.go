@@ -0,0 +1,84 @@
+package hashid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	key := []byte("secret")
+
+	id, err := New("user@example.com", WithHMACKey(key))
+	require.NoError(t, err)
+
+	uid, err := NewUUID("user@example.com", WithHMACKey(key))
+	require.NoError(t, err)
+
+	sid, err := NewShortID("user@example.com", WithHMACKey(key))
+	require.NoError(t, err)
+
+	ok, err := Verify("user@example.com", id, WithHMACKey(key))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("user@example.com", uid, WithHMACKey(key))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("user@example.com", sid, WithHMACKey(key))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// normalization still applies to the input
+	ok, err = Verify("  User@Example.com ", id, WithHMACKey(key))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("other@example.com", id, WithHMACKey(key))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = Verify("user@example.com", id, WithHMACKey([]byte("other")))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerifyNamedStringType(t *testing.T) {
+	type UserID string
+
+	id, err := New("user@example.com")
+	require.NoError(t, err)
+
+	ok, err := Verify("user@example.com", UserID(id))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestVerifyErrors(t *testing.T) {
+	_, err := Verify("user@example.com", "not-an-id")
+	assert.Error(t, err)
+
+	_, err = Verify("user@example.com", "ddea575a-d5e2-3114-9267-dbead79c4ab8", WithHashAlgorithm("unknown"))
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestParseID(t *testing.T) {
+	uid, err := NewUUID("user@example.com")
+	require.NoError(t, err)
+
+	sid, err := NewShortID("user@example.com")
+	require.NoError(t, err)
+
+	for _, id := range []string{uid.String(), "urn:uuid:" + uid.String(), sid} {
+		parsed, err := ParseID(id)
+		require.NoError(t, err, id)
+		assert.Equal(t, uid, parsed, id)
+	}
+
+	for _, id := range []string{"", "junk", "not-a-uuid"} {
+		_, err = ParseID(id)
+		assert.Error(t, err, id)
+	}
+}
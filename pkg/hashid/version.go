@@ -0,0 +1,71 @@
+package hashid
+
+import "fmt"
+
+// legacyVersion is the UUID version earlier releases stamped on
+// SHA256 IDs and on HMAC_SHA256 IDs selected implicitly through
+// WithHMACKey, SHA1 used version 5 and HMAC_SHA256 set with
+// WithHashAlgorithm version 8 as they do now.
+const legacyVersion = 3
+
+// WithStrictVersion makes NewGenerator return an error wrapping
+// ErrVersionAlgorithmMismatch when the UUID version does not match
+// the hashing algorithm, e.g. MD5 with version 5. Without it the
+// mismatch is reported by Generator.Warnings.
+func WithStrictVersion() Option {
+	return func(o *options) {
+		o.strictVersion = true
+	}
+}
+
+// WithLegacyVersions keeps the UUID versions of earlier releases
+// so that already issued IDs can still be generated and verified:
+// without WithUUIDVersion SHA256 and the HMAC_SHA256 selected by
+// WithHMACKey alone produce version 3 instead of 8, and version 3
+// set with WithUUIDVersion is not reported as a mismatch.
+func WithLegacyVersions() Option {
+	return func(o *options) {
+		o.legacyVersions = true
+	}
+}
+
+// resolveVersion returns the UUID version to stamp on IDs,
+// version 0 selects the default of the algorithm
+func resolveVersion(config options, spec algorithm) (int, error) {
+	version := config.uuidVersion
+	if version == 0 {
+		version = spec.version
+		implicitHMAC := config.hmacKey != nil && !config.hashAlgoSet
+		if config.legacyVersions && (config.hashAlgo == SHA256 || implicitHMAC) {
+			version = legacyVersion
+		}
+	}
+
+	switch version {
+	case 3, 5, 8:
+		return version, nil
+	default:
+		return 0, &ConfigError{
+			Field: "version",
+			Err:   fmt.Errorf("%w: %d, should be one of 3, 5, 8", ErrUnsupportedVersion, version),
+		}
+	}
+}
+
+// checkVersion reports whether the version set with WithUUIDVersion
+// matches the algorithm, the default version always matches
+func checkVersion(config options, spec algorithm, version int) error {
+	if config.uuidVersion == 0 || version == spec.version {
+		return nil
+	}
+
+	if config.legacyVersions && version == legacyVersion {
+		return nil
+	}
+
+	return &ConfigError{
+		Field: "version",
+		Err: fmt.Errorf("%w: %s with version %d, expected version %d",
+			ErrVersionAlgorithmMismatch, config.hashAlgo, version, spec.version),
+	}
+}
@@ -0,0 +1,131 @@
+package hashid
+
+import (
+	"crypto/sha256"
+	"hash"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSHA256Version(t *testing.T) {
+	uid, err := NewUUID("user@example.com", WithHashAlgorithm(SHA256), WithNormalization(false))
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(8), uid.Version())
+
+	sum := sha256.Sum256([]byte("user@example.com"))
+	assert.Equal(t, formatUUID(sum[:], 8), uid)
+}
+
+func TestLegacyVersions(t *testing.T) {
+	sum := sha256.Sum256([]byte("user@example.com"))
+
+	// the option order does not matter
+	for _, opts := range [][]Option{
+		{WithHashAlgorithm(SHA256), WithLegacyVersions()},
+		{WithLegacyVersions(), WithHashAlgorithm(SHA256)},
+	} {
+		gen, err := NewGenerator(append(opts, WithStrictVersion(), WithNormalization(false))...)
+		require.NoError(t, err)
+		assert.Empty(t, gen.Warnings())
+
+		uid, err := gen.NewUUID("user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, formatUUID(sum[:], 3), uid)
+	}
+
+	// IDs from WithHMACKey without an algorithm, the default
+	// version is never reported as a mismatch
+	key := []byte("secret")
+	gen, err := NewGenerator(WithHMACKey(key), WithStrictVersion())
+	require.NoError(t, err)
+	assert.Equal(t, 8, gen.Version())
+	assert.Empty(t, gen.Warnings())
+
+	gen, err = NewGenerator(WithHMACKey(key), WithStrictVersion(), WithLegacyVersions())
+	require.NoError(t, err)
+	assert.Equal(t, 3, gen.Version())
+
+	// other algorithms keep the versions earlier releases used
+	testCases := []struct {
+		algo    HashAlgorithm
+		version int
+	}{
+		{MD5, 3},
+		{SHA1, 5},
+		{SHA256, 3},
+		{HMAC_SHA256, 8},
+		{BLAKE3, 8},
+	}
+	for _, tc := range testCases {
+		opts := []Option{WithHashAlgorithm(tc.algo), WithLegacyVersions(), WithStrictVersion()}
+		if tc.algo.Keyed() {
+			opts = append(opts, WithHMACKey(key))
+		}
+		gen, err := NewGenerator(opts...)
+		require.NoError(t, err, tc.algo)
+		assert.Equal(t, tc.version, gen.Version(), tc.algo)
+	}
+
+	// version 3 set explicitly is accepted in legacy mode
+	gen, err = NewGenerator(WithHashAlgorithm(SHA1), WithUUIDVersion(3), WithLegacyVersions(), WithStrictVersion())
+	require.NoError(t, err)
+	assert.Equal(t, 3, gen.Version())
+
+	// an explicit version still wins
+	gen, err = NewGenerator(WithHashAlgorithm(SHA1), WithUUIDVersion(5), WithLegacyVersions())
+	require.NoError(t, err)
+	assert.Equal(t, 5, gen.Version())
+	assert.Empty(t, gen.Warnings())
+}
+
+func TestVersionAlgorithmMismatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  []Option
+		mismatch bool
+	}{
+		{"MD5 default", nil, false},
+		{"MD5 with version 3", []Option{WithUUIDVersion(3)}, false},
+		{"MD5 with version 5", []Option{WithUUIDVersion(5)}, true},
+		{"SHA1 with version 5", []Option{WithHashAlgorithm(SHA1)}, false},
+		{"SHA1 with version 3", []Option{WithHashAlgorithm(SHA1), WithUUIDVersion(3)}, true},
+		{"SHA256 with version 8", []Option{WithHashAlgorithm(SHA256), WithUUIDVersion(8)}, false},
+		{"SHA256 with version 3", []Option{WithHashAlgorithm(SHA256), WithUUIDVersion(3)}, true},
+		{"BLAKE3 with version 5", []Option{WithHashAlgorithm(BLAKE3), WithUUIDVersion(5)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen, err := NewGenerator(tc.options...)
+			require.NoError(t, err)
+
+			_, err = NewGenerator(append(tc.options, WithStrictVersion())...)
+
+			if tc.mismatch {
+				require.Len(t, gen.Warnings(), 1)
+				assert.ErrorIs(t, gen.Warnings()[0], ErrVersionAlgorithmMismatch)
+				assert.ErrorIs(t, err, ErrVersionAlgorithmMismatch)
+				return
+			}
+
+			assert.Empty(t, gen.Warnings())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestVersionSentinelErrors(t *testing.T) {
+	_, err := New("test", WithUUIDVersion(10))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = New("test", WithHashAlgorithm(HMAC_SHA512))
+	assert.ErrorIs(t, err, ErrMissingKey)
+
+	err = RegisterAlgorithm("version-sentinel", func([]byte) (hash.Hash, error) {
+		return sha256.New(), nil
+	}, 4)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}